@@ -2,26 +2,183 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// ErrDialogCancelled is returned when the user dismisses a file dialog.
+var ErrDialogCancelled = errors.New("dialog cancelled")
+
 // App struct
 type App struct {
 	ctx context.Context
+
+	mu       sync.Mutex
+	lastDir  string
+	settings *Settings
+	meshes   *meshRegistry
+	watcher  *fileWatcher
+
+	// recentItem is the Open Recent entry of the menu bar, whose submenu is
+	// replaced whenever the recent-files list changes. menuMu serialises
+	// the replacements, which come from every load goroutine.
+	recentItem *menu.MenuItem
+	menuMu     sync.Mutex
+
+	// loads maps the ID of each running LoadAsync task to its cancel
+	// function.
+	loads    map[string]context.CancelFunc
+	nextLoad int
+
+	// fileHashes caches modelHash results for files that are not loaded,
+	// guarded by hashMu.
+	fileHashes map[string]fileHash
+	hashMu     sync.Mutex
+
+	// windowReset is set by ResetSettings to stop the window geometry being
+	// saved again on close.
+	windowReset bool
+
+	// startupPath is a model passed on the command line, loaded once the
+	// frontend is ready.
+	startupPath string
 }
 
 // NewApp creates a new App application struct
-func NewApp() *App {
-	return &App{}
+func NewApp(settings *Settings) *App {
+	a := &App{settings: settings, meshes: newMeshRegistry(settings.MaxCacheBytes)}
+	a.meshes.onEvict = a.modelEvicted
+	return a
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	runtime.OnFileDrop(ctx, a.onFileDrop)
+}
+
+// domReady is called once the frontend has loaded and the window exists.
+func (a *App) domReady(ctx context.Context) {
+	a.restoreWindowPosition(ctx)
+
+	if a.startupPath != "" {
+		go a.loadAndEmit(a.startupPath)
+	}
+}
+
+// onSecondInstanceLaunch receives the arguments of a second launch, which
+// exits immediately, and opens its model in this window instead.
+func (a *App) onSecondInstanceLaunch(data options.SecondInstanceData) {
+	runtime.WindowUnminimise(a.ctx)
+	runtime.WindowShow(a.ctx)
+
+	if path := modelPathFromArgs(data.Args, data.WorkingDirectory); path != "" {
+		go a.loadAndEmit(path)
+	}
+}
+
+// LoadModelAsync starts loading path in the background and returns
+// immediately. Progress and the result are delivered as model:progress,
+// model:loaded and model:error events.
+func (a *App) LoadModelAsync(path string) {
+	go a.loadAndEmit(path)
+}
+
+// loadAndEmit loads path and reports progress and the outcome through
+// runtime events.
+func (a *App) loadAndEmit(path string) {
+	mesh, err := a.openModel(context.Background(), path, newProgressEmitter(a.ctx, path).report)
+	if err != nil {
+		runtime.EventsEmit(a.ctx, eventModelError, path, err.Error())
+		return
+	}
+	runtime.EventsEmit(a.ctx, eventModelLoaded, path, mesh.descriptor())
+}
+
+// beforeClose persists window geometry. Returning false lets the window close.
+func (a *App) beforeClose(ctx context.Context) bool {
+	a.saveWindowState(ctx)
+	return false
+}
+
+// persistSettings writes the current settings to disk.
+func (a *App) persistSettings() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return saveSettings(a.settings)
 }
 
 // Greet returns a greeting for the given name
 func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
+
+// OpenModelDialog shows the native file picker filtered to supported formats
+// and loads the chosen file. Like LoadModel it returns a descriptor whose
+// buffers are fetched from the asset server.
+func (a *App) OpenModelDialog() (*MeshDescriptor, error) {
+	path, err := a.chooseModelPath()
+	if err != nil {
+		return nil, err
+	}
+	mesh, err := a.openModel(context.Background(), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return mesh.descriptor(), nil
+}
+
+// chooseModelPath shows the open dialog, starting in the last directory used,
+// and returns the chosen file.
+func (a *App) chooseModelPath() (string, error) {
+	a.mu.Lock()
+	dir := a.lastDir
+	a.mu.Unlock()
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:            "Open Model",
+		DefaultDirectory: dir,
+		Filters:          dialogFilters(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", ErrDialogCancelled
+	}
+
+	a.mu.Lock()
+	a.lastDir = filepath.Dir(path)
+	a.mu.Unlock()
+	return path, nil
+}
+
+// dialogFilters lists every loadable format, preceded by a combined entry.
+func dialogFilters() []runtime.FileFilter {
+	var all []string
+	filters := []runtime.FileFilter{{DisplayName: "3D Models"}}
+	for _, f := range supportedFormats() {
+		if !f.CanLoad {
+			continue
+		}
+		var patterns []string
+		for _, ext := range f.Extensions {
+			patterns = append(patterns, "*"+ext, "*"+ext+".gz")
+		}
+		all = append(all, patterns...)
+		filters = append(filters, runtime.FileFilter{
+			DisplayName: fmt.Sprintf("%s (%s)", f.Name, strings.Join(patterns, ", ")),
+			Pattern:     strings.Join(patterns, ";"),
+		})
+	}
+	filters[0].Pattern = strings.Join(all, ";")
+	return filters
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// meshBufferRoute is the asset server path under which raw mesh buffers are
+// served, as /meshbuf/<meshID>/<buffer>.
+const meshBufferRoute = "/meshbuf/"
+
+// meshBufferChunk is the number of values encoded per write when streaming.
+const meshBufferChunk = 16 * 1024
+
+// MeshDescriptor describes a loaded mesh without its geometry. The frontend
+// fetches each buffer from its URL as little-endian binary, which avoids
+// marshalling large arrays through the JSON bridge.
+type MeshDescriptor struct {
+	ID            string             `json:"id"`
+	VertexCount   int                `json:"vertexCount"`
+	TriangleCount int                `json:"triangleCount"`
+	Min           [3]float64         `json:"min"`
+	Max           [3]float64         `json:"max"`
+	PointCloud    bool               `json:"pointCloud,omitempty"`
+	Materials     []Material         `json:"materials"`
+	Groups        []SubMesh          `json:"groups,omitempty"`
+	Buffers       []BufferDescriptor `json:"buffers"`
+}
+
+// BufferDescriptor locates one tightly packed vertex attribute or the index
+// buffer.
+type BufferDescriptor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// ComponentType is "float32" or "uint32".
+	ComponentType string `json:"componentType"`
+	// Components is the number of values per element, e.g. 3 for positions.
+	Components int `json:"components"`
+	Count      int `json:"count"`
+	// Stride is the distance in bytes between consecutive elements.
+	Stride     int `json:"stride"`
+	ByteLength int `json:"byteLength"`
+}
+
+// LoadModel loads path and returns a descriptor whose buffers are served over
+// the asset server rather than embedded in the response.
+func (a *App) LoadModel(path string) (*MeshDescriptor, error) {
+	mesh, err := a.openModel(context.Background(), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return mesh.descriptor(), nil
+}
+
+// descriptor describes m as it is now, reading it under buffersMu.
+func (m *Mesh) descriptor() *MeshDescriptor {
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	d := &MeshDescriptor{
+		ID:            m.ID,
+		VertexCount:   m.VertexCount(),
+		TriangleCount: m.TriangleCount(),
+		PointCloud:    m.PointCloud,
+		Materials:     m.Materials,
+		Groups:        m.Groups,
+	}
+	d.Min, d.Max = m.bounds()
+
+	for _, b := range meshBuffers {
+		n := b.length(m)
+		if n == 0 {
+			continue
+		}
+		d.Buffers = append(d.Buffers, BufferDescriptor{
+			Name:          b.name,
+			URL:           meshBufferRoute + m.ID + "/" + b.name,
+			ComponentType: b.componentType,
+			Components:    b.components,
+			Count:         n / b.components,
+			Stride:        b.components * 4,
+			ByteLength:    n * 4,
+		})
+	}
+	return d
+}
+
+// meshBuffer names one servable array of a mesh. Every component type used
+// is four bytes wide.
+type meshBuffer struct {
+	name          string
+	componentType string
+	components    int
+	// Exactly one of floats and uints is set, matching componentType.
+	floats func(m *Mesh) []float32
+	uints  func(m *Mesh) []uint32
+}
+
+var meshBuffers = []meshBuffer{
+	{name: "positions", componentType: "float32", components: 3, floats: func(m *Mesh) []float32 { return m.Vertices }},
+	{name: "normals", componentType: "float32", components: 3, floats: func(m *Mesh) []float32 { return m.Normals }},
+	{name: "uvs", componentType: "float32", components: 2, floats: func(m *Mesh) []float32 { return m.UVs }},
+	{name: "colors", componentType: "float32", components: 4, floats: func(m *Mesh) []float32 { return m.Colors }},
+	{name: "indices", componentType: "uint32", components: 1, uints: func(m *Mesh) []uint32 { return m.Indices }},
+	{name: "lines", componentType: "uint32", components: 2, uints: func(m *Mesh) []uint32 { return m.Lines }},
+	{name: "points", componentType: "uint32", components: 1, uints: func(m *Mesh) []uint32 { return m.Points }},
+}
+
+func (b meshBuffer) length(m *Mesh) int {
+	if b.uints != nil {
+		return len(b.uints(m))
+	}
+	return len(b.floats(m))
+}
+
+func findMeshBuffer(name string) (meshBuffer, bool) {
+	for _, b := range meshBuffers {
+		if b.name == name {
+			return b, true
+		}
+	}
+	return meshBuffer{}, false
+}
+
+// meshBufferHandler serves registered mesh buffers to the asset server. Wails
+// only calls it for paths not found in the embedded assets.
+type meshBufferHandler struct {
+	meshes *meshRegistry
+}
+
+func newMeshBufferHandler(meshes *meshRegistry) *meshBufferHandler {
+	return &meshBufferHandler{meshes: meshes}
+}
+
+func (h *meshBufferHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rest, ok := strings.CutPrefix(r.URL.Path, meshBufferRoute)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id, name, ok := strings.Cut(rest, "/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	buf, ok := findMeshBuffer(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	m, err := h.meshes.get(id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The lock is held until the body is written: in-place edits such as
+	// ConvertUnits must not change the values, or swap the slice, mid-way.
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	var floats []float32
+	var uints []uint32
+	if buf.uints != nil {
+		uints = buf.uints(m)
+	} else {
+		floats = buf.floats(m)
+	}
+	n := len(floats) + len(uints)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(n*4))
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	// Encode in chunks so a large buffer is never duplicated in full.
+	out := make([]byte, 0, meshBufferChunk*4)
+	for start := 0; start < n; start += meshBufferChunk {
+		end := min(start+meshBufferChunk, n)
+		out = out[:0]
+		if uints != nil {
+			for _, v := range uints[start:end] {
+				out = binary.LittleEndian.AppendUint32(out, v)
+			}
+		} else {
+			for _, v := range floats[start:end] {
+				out = binary.LittleEndian.AppendUint32(out, math.Float32bits(v))
+			}
+		}
+		if _, err := w.Write(out); err != nil {
+			return
+		}
+	}
+}
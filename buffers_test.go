@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestMeshBufferServedWhileEdited(t *testing.T) {
+	a := NewApp(defaultSettings())
+	verts := make([]float32, 3*50_000)
+	for i := range verts {
+		verts[i] = float32(i % 100)
+	}
+	uvs := make([]float32, 2*50_000)
+	m := a.meshes.add(&Mesh{Vertices: verts, UVs: uvs, Indices: []uint32{0, 1, 2}, Units: "mm"})
+	h := newMeshBufferHandler(a.meshes)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			to := "cm"
+			if i%2 == 1 {
+				to = "mm"
+			}
+			if err := a.ConvertUnits(m.ID, "", to); err != nil {
+				t.Error(err)
+			}
+			m.setFlipV(i%2 == 0)
+		}
+	}()
+	for _, name := range []string{"positions", "uvs", "positions", "uvs"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", meshBufferRoute+m.ID+"/"+name, nil))
+		if got := rec.Header().Get("Content-Length"); got != strconv.Itoa(rec.Body.Len()) {
+			t.Errorf("%s: Content-Length %s for a %d-byte body", name, got, rec.Body.Len())
+		}
+	}
+	wg.Wait()
+}
+
+func TestMeshReadWhileEdited(t *testing.T) {
+	a := NewApp(defaultSettings())
+	m := a.meshes.add(gridMesh(8))
+	dir := t.TempDir()
+
+	reads := map[string]func() error{
+		"stats":    func() error { _, err := a.ComputeStats(m.ID); return err },
+		"validate": func() error { _, err := a.ValidateMesh(m.ID); return err },
+		"scalar":   func() error { _, err := a.ComputeScalarField(m.ID, "curvature"); return err },
+		"slice":    func() error { _, err := a.SlicePlane(m.ID, [3]float64{4, 4, 0}, [3]float64{1, 0, 0}); return err },
+		"camera":   func() error { _, err := a.GetCameraFit(m.ID, 45, 1); return err },
+		"export":   func() error { return a.ExportModel(m.ID, filepath.Join(dir, "part.stl"), "") },
+		"json":     func() error { _, err := json.Marshal(m); return err },
+		"describe": func() error { m.descriptor(); return nil },
+		// Derived meshes are dropped again so the cache stays small.
+		"derive": func() error {
+			simplified, err := a.Simplify(m.ID, 0.5)
+			if err != nil {
+				return err
+			}
+			welded, err := a.WeldVertices(m.ID, 0, false)
+			if err != nil {
+				return err
+			}
+			repaired, err := a.RepairMesh(m.ID, 0)
+			if err != nil {
+				return err
+			}
+			split, err := a.SplitComponents(m.ID, 0)
+			if err != nil {
+				return err
+			}
+			ids := []string{simplified.ID, welded.Mesh.ID, repaired.Mesh.ID}
+			for _, c := range split.Components {
+				ids = append(ids, c.ID)
+			}
+			for _, id := range ids {
+				if err := a.meshes.remove(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for name, read := range reads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if err := read(); err != nil {
+					t.Errorf("%s: %v", name, err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := a.RecomputeNormals(m.ID, i%2 == 0, true); err != nil {
+			t.Error(err)
+		}
+		if _, err := a.ApplyTransform(m.ID, translationMat4([3]float64{1, 0, 0})); err != nil {
+			t.Error(err)
+		}
+		if _, err := a.UnifyWinding(m.ID); err != nil {
+			t.Error(err)
+		}
+		if err := a.SetFlipV(m.ID, i%2 == 0); err != nil {
+			t.Error(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// openModel returns the cached mesh for path when the file is unchanged since
+// it was loaded, and otherwise parses it and caches the result.
+func (a *App) openModel(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		if mesh, ok := a.meshes.lookupPath(abs); ok {
+			a.stopWatchingOthers(a.addRecentFile(abs, mesh.Meta.Hash))
+			appLog.debug("model reused from cache", "path", abs, "id", mesh.ID)
+			return mesh, nil
+		}
+	}
+	mesh, err := loadModel(ctx, path, progress)
+	if err != nil {
+		return nil, err
+	}
+	return a.modelLoaded(path, mesh), nil
+}
+
+// ReloadModel re-reads a mesh from its source file even if the cached copy
+// is current. The result keeps the mesh ID.
+func (a *App) ReloadModel(meshID string) (*Mesh, error) {
+	path, err := a.meshes.source(meshID)
+	if err != nil {
+		return nil, err
+	}
+	return a.reloadMesh(meshID, path)
+}
+
+func (a *App) reloadMesh(meshID, path string) (*Mesh, error) {
+	mesh, err := loadModel(context.Background(), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	// A unit conversion, V flip or transform made by the user outlives the
+	// reload. The transform is stated in the converted unit, so the
+	// conversion comes first.
+	a.assignUnits(mesh)
+	if old, err := a.meshes.get(meshID); err == nil {
+		old.buffersMu.RLock()
+		units, flipV, transform := old.Units, old.FlipV, old.Transform
+		old.buffersMu.RUnlock()
+		_, known := unitMetres[mesh.Units]
+		if _, oldKnown := unitMetres[units]; known && oldKnown && units != mesh.Units {
+			mesh.convertUnits(mesh.Units, units)
+		}
+		mesh.setFlipV(flipV)
+		if transform != nil {
+			mesh.transform(mat4(transform.Matrix))
+		}
+	}
+	if err := a.meshes.replace(meshID, mesh); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
+
+// UnloadModel frees a cached mesh. Later calls with its ID fail with
+// ErrMeshNotFound.
+func (a *App) UnloadModel(meshID string) error {
+	if err := a.meshes.remove(meshID); err != nil {
+		return err
+	}
+	a.stopWatching(meshID)
+	return nil
+}
+
+// ListLoadedModels returns the cached meshes, most recently used first.
+func (a *App) ListLoadedModels() []ModelInfo {
+	return a.meshes.list()
+}
+
+// SetCacheLimit sets the total size in bytes of decoded meshes kept in
+// memory and persists it. The least recently used meshes are evicted first.
+func (a *App) SetCacheLimit(maxBytes int64) error {
+	if maxBytes <= 0 {
+		return fmt.Errorf("cache: limit must be positive, got %d", maxBytes)
+	}
+	a.meshes.setLimit(maxBytes)
+	a.mu.Lock()
+	a.settings.MaxCacheBytes = maxBytes
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+// modelEvicted tells the frontend a mesh was dropped to stay under the cache
+// limit.
+func (a *App) modelEvicted(id, path string) {
+	appLog.info("model evicted from cache", "id", id, "path", path)
+	a.stopWatching(id)
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelEvicted, id, path)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadKeepsUnitsBeforeTransform(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "tri.obj")
+	if err := os.WriteFile(path, []byte("v 0 0 0\nv 10 0 0\nv 0 10 0\nf 1 2 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := NewApp(defaultSettings())
+	m, err := a.openModel(context.Background(), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Units != "mm" {
+		t.Fatalf("loaded in %q, want mm", m.Units)
+	}
+	// Move 5 mm along X, then restate in cm: the vertex at 10 mm ends at 1.5 cm.
+	m.transform(translationMat4([3]float64{5, 0, 0}))
+	m.convertUnits(m.Units, "cm")
+	want := m.Vertices[3]
+
+	reloaded, err := a.ReloadModel(m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Units != "cm" || reloaded.Vertices[3] != want {
+		t.Errorf("reloaded x = %v %s, want %v cm", reloaded.Vertices[3], reloaded.Units, want)
+	}
+}
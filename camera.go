@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// cameraFitMargin enlarges the bounding sphere so the model does not touch
+// the edges of the view.
+const cameraFitMargin = 1.1
+
+// CameraPose is a suggested perspective camera for a mesh.
+type CameraPose struct {
+	Target [3]float64 `json:"target"`
+	Eye    [3]float64 `json:"eye"`
+	Up     [3]float64 `json:"up"`
+	Near   float64    `json:"near"`
+	Far    float64    `json:"far"`
+	// Radius is the bounding sphere radius around Target, useful for orbit
+	// control limits.
+	Radius float64 `json:"radius"`
+}
+
+// GetCameraFit frames a mesh for a perspective camera with the given vertical
+// field of view and aspect ratio (width / height). The camera looks at the
+// centroid from the same three-quarter direction used for thumbnails, turned
+// to suit the up axis of the viewer preferences.
+func (a *App) GetCameraFit(meshID string, fovYDegrees float64, aspect float64) (*CameraPose, error) {
+	if !(fovYDegrees > 0 && fovYDegrees < 180) {
+		return nil, fmt.Errorf("camera: field of view %v must be between 0 and 180 degrees", fovYDegrees)
+	}
+	if !(aspect > 0) || math.IsInf(aspect, 0) {
+		return nil, fmt.Errorf("camera: invalid aspect ratio %v", aspect)
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	upAxis := a.upAxis()
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	return m.cameraFit(fovYDegrees*math.Pi/180, aspect, upAxis), nil
+}
+
+func (m *Mesh) cameraFit(fovY, aspect float64, upAxis string) *CameraPose {
+	target := m.stats().Centroid
+	var radius float64
+	for v := 0; v < m.VertexCount(); v++ {
+		radius = math.Max(radius, length(sub(m.vertex(uint32(v)), target)))
+	}
+	return fitSphere(target, radius, upAxis, fovY, aspect)
+}
+
+// fitSphere places a camera looking at a sphere from the three-quarter view
+// of an up axis so the sphere fills the view.
+func fitSphere(target [3]float64, radius float64, upAxis string, fovY, aspect float64) *CameraPose {
+	if radius == 0 {
+		radius = 1
+	}
+
+	// The sphere has to fit the narrower of the two half-angles.
+	half := math.Min(fovY/2, math.Atan(math.Tan(fovY/2)*aspect))
+	fit := radius * cameraFitMargin
+	dist := fit / math.Sin(half)
+	dir, up := viewBasis(upAxis)
+
+	// Keeping near and far proportional to the model size keeps depth
+	// precision the same whether the model is measured in microns or metres.
+	return &CameraPose{
+		Target: target,
+		Eye:    add(target, scale(dir, dist)),
+		Up:     up,
+		Near:   math.Max(dist-fit, dist*0.01),
+		Far:    dist + fit*2,
+		Radius: radius,
+	}
+}
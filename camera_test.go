@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// cubeMesh returns a closed cube of the given side centred on centre.
+func cubeMesh(side float32, centre [3]float32) *Mesh {
+	m := &Mesh{}
+	for i := 0; i < 8; i++ {
+		for k := 0; k < 3; k++ {
+			c := -side / 2
+			if i>>k&1 == 1 {
+				c = side / 2
+			}
+			m.Vertices = append(m.Vertices, centre[k]+c)
+		}
+	}
+	m.Indices = []uint32{
+		0, 2, 1, 1, 2, 3, 4, 5, 6, 5, 7, 6,
+		0, 1, 4, 1, 5, 4, 2, 6, 3, 3, 6, 7,
+		0, 4, 2, 2, 4, 6, 1, 3, 5, 3, 7, 5,
+	}
+	return m
+}
+
+func TestGetCameraFit(t *testing.T) {
+	a := NewApp(defaultSettings())
+	var ratio float64
+	tests := []struct {
+		name   string
+		side   float32
+		centre [3]float32
+		fovY   float64
+		aspect float64
+	}{
+		{"unit cube", 1, [3]float32{0, 0, 0}, 60, 1},
+		{"unit cube wide", 1, [3]float32{0, 0, 0}, 45, 16.0 / 9},
+		{"unit cube tall", 1, [3]float32{0, 0, 0}, 60, 0.5},
+		{"tiny", 1e-4, [3]float32{2e-4, 0, 0}, 60, 1},
+		{"tiny tall", 1e-4, [3]float32{0, 0, 0}, 30, 0.75},
+		{"huge", 1e5, [3]float32{0, 5e4, 0}, 60, 1},
+		{"huge wide", 1e5, [3]float32{0, 0, 0}, 90, 2.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := a.meshes.add(cubeMesh(tt.side, tt.centre))
+			pose, err := a.GetCameraFit(m.ID, tt.fovY, tt.aspect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			size := float64(tt.side)
+			tol := size * 1e-4
+
+			wantRadius := size * math.Sqrt(3) / 2
+			if math.Abs(pose.Radius-wantRadius) > tol {
+				t.Errorf("radius = %v, want %v", pose.Radius, wantRadius)
+			}
+			for k := 0; k < 3; k++ {
+				if math.Abs(pose.Target[k]-float64(tt.centre[k])) > tol {
+					t.Errorf("target = %v, want %v", pose.Target, tt.centre)
+				}
+			}
+
+			// The margin-enlarged sphere must exactly fill the narrower of the
+			// vertical and horizontal half-angles.
+			dist := length(sub(pose.Eye, pose.Target))
+			halfY := tt.fovY * math.Pi / 360
+			halfX := math.Atan(math.Tan(halfY) * tt.aspect)
+			subtended := math.Asin(pose.Radius * cameraFitMargin / dist)
+			if want := math.Min(halfY, halfX); math.Abs(subtended-want) > 1e-9 {
+				t.Errorf("sphere subtends %v rad from %v away, want %v", subtended, dist, want)
+			}
+
+			if !(pose.Near > 0 && pose.Near < dist-pose.Radius) {
+				t.Errorf("near = %v, want in (0, %v)", pose.Near, dist-pose.Radius)
+			}
+			if pose.Far <= dist+pose.Radius {
+				t.Errorf("far = %v, want beyond %v", pose.Far, dist+pose.Radius)
+			}
+			// Depth precision depends only on far/near, which must not change
+			// with the size of the model.
+			if tt.fovY == 60 && tt.aspect == 1 {
+				r := pose.Far / pose.Near
+				if ratio == 0 {
+					ratio = r
+				} else if math.Abs(r-ratio) > ratio*1e-6 {
+					t.Errorf("far/near = %v, want %v as for the unit cube", r, ratio)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "fmt"
+
+// CenterModel moves a mesh so its bounding box is centred on the origin
+// across the ground plane, and along the up axis of the viewer preferences
+// too unless toGround is set, in which case its lowest point is moved to
+// zero on that axis. The translation is baked into the vertices, so stats,
+// buffers and exports all see it, and is returned; model:updated carries the
+// moved mesh. ClearTransform undoes it.
+func (a *App) CenterModel(meshID string, toGround bool) (*Transform, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	if m.VertexCount() == 0 {
+		m.buffersMu.RUnlock()
+		return nil, fmt.Errorf("center: mesh %q has no vertices", meshID)
+	}
+	min, max := m.bounds()
+	m.buffersMu.RUnlock()
+
+	t := scale(add(min, max), -0.5)
+	if toGround {
+		up := upIndex(a.upAxis())
+		t[up] = -min[up]
+	}
+	m.transform(translationMat4(t))
+	a.modelMoved(m)
+	return newTransform(translationMat4(t)), nil
+}
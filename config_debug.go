@@ -1,5 +1,9 @@
 //go:build !release
 
+// Window defaults for development builds (`wails dev`, `wails build`):
+// a normal framed window that starts maximised. Keep the variable set in
+// sync with config_release.go.
+
 package main
 
 import "github.com/wailsapp/wails/v2/pkg/options"
@@ -1,5 +1,9 @@
 //go:build release
 
+// Window defaults for `wails build -tags release` (`task build`): a
+// frameless window that starts fullscreen. Saved window geometry is not
+// applied in this mode. Keep the variable set in sync with config_debug.go.
+
 package main
 
 import "github.com/wailsapp/wails/v2/pkg/options"
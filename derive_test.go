@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+// gridMesh returns an n by n grid of quads split into two groups, the left
+// half on material 0 and the right half on material 1.
+func gridMesh(n int) *Mesh {
+	m := &Mesh{
+		Materials: []Material{{Name: "left"}, {Name: "right"}},
+		Units:     "cm",
+		FlipV:     true,
+		Transform: newTransform(translationMat4([3]float64{1, 2, 3})),
+	}
+	for y := 0; y <= n; y++ {
+		for x := 0; x <= n; x++ {
+			m.Vertices = append(m.Vertices, float32(x), float32(y), 0)
+			m.UVs = append(m.UVs, float32(x)/float32(n), float32(y)/float32(n))
+		}
+	}
+	for _, half := range []int{0, 1} {
+		start := len(m.Indices)
+		for y := 0; y < n; y++ {
+			for x := half * n / 2; x < (half+1)*n/2; x++ {
+				v := uint32(y*(n+1) + x)
+				w := uint32(n + 1)
+				m.Indices = append(m.Indices, v, v+1, v+w+1, v, v+w+1, v+w)
+			}
+		}
+		m.Groups = append(m.Groups, SubMesh{Name: m.Materials[half].Name, Start: start, Count: len(m.Indices) - start, Material: half})
+	}
+	return m
+}
+
+func TestDerivedMeshesKeepSourceState(t *testing.T) {
+	src := gridMesh(8)
+	for name, out := range map[string]*Mesh{
+		"weld":     src.weld(0, true),
+		"repair":   src.repair(0).Mesh,
+		"simplify": src.simplify(0.5),
+	} {
+		if !out.FlipV || out.Transform == nil || out.Transform.Matrix != src.Transform.Matrix || out.Units != "cm" {
+			t.Errorf("%s: FlipV %v, transform %+v, units %q; want the source's", name, out.FlipV, out.Transform, out.Units)
+		}
+		if len(out.Groups) != 2 {
+			t.Errorf("%s: groups = %+v, want 2", name, out.Groups)
+			continue
+		}
+		covered := 0
+		for i, g := range out.Groups {
+			if g.Material != i || g.Start != covered || g.Count == 0 {
+				t.Errorf("%s: group %d = %+v", name, i, g)
+			}
+			covered += g.Count
+		}
+		if covered != len(out.Indices) {
+			t.Errorf("%s: groups cover %d of %d indices", name, covered, len(out.Indices))
+		}
+	}
+}
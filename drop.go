@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// onFileDrop handles files dropped anywhere on the window. Directories and
+// unsupported files are skipped; the first supported file is loaded.
+func (a *App) onFileDrop(_, _ int, paths []string) {
+	var supported []string
+	for _, p := range paths {
+		if info, err := os.Stat(p); err != nil || info.IsDir() {
+			continue
+		}
+		if formatForExtension(p) != nil {
+			supported = append(supported, p)
+		}
+	}
+
+	switch {
+	case len(supported) == 0:
+		if len(paths) > 0 {
+			runtime.EventsEmit(a.ctx, eventModelError, paths[0], "none of the dropped files is a supported model")
+		}
+		return
+	case len(supported) > 1:
+		var names []string
+		for _, p := range supported[1:] {
+			names = append(names, filepath.Base(p))
+		}
+		runtime.EventsEmit(a.ctx, eventModelError, supported[0],
+			fmt.Sprintf("multiple models dropped; loading %s and ignoring %s", filepath.Base(supported[0]), strings.Join(names, ", ")))
+	}
+
+	a.mu.Lock()
+	a.lastDir = filepath.Dir(supported[0])
+	a.mu.Unlock()
+
+	a.loadAndEmit(supported[0])
+}
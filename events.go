@@ -0,0 +1,54 @@
+package main
+
+// Runtime event names emitted to the frontend.
+const (
+	// eventModelLoaded carries the source path and the *MeshDescriptor of the
+	// decoded mesh.
+	eventModelLoaded = "model:loaded"
+	// eventModelError carries the source path and an error message.
+	eventModelError = "model:error"
+	// eventModelProgress carries the source path and a 0-100 percentage.
+	eventModelProgress = "model:progress"
+	// eventModelReloaded carries the source path and the *MeshDescriptor of
+	// the re-read mesh, which keeps the ID of the mesh it replaces.
+	eventModelReloaded = "model:reloaded"
+	// eventModelRescaled carries the mesh ID and its *MeshStats after a unit
+	// conversion.
+	eventModelRescaled = "model:rescaled"
+	// eventModelEvicted carries the mesh ID and source path of a mesh dropped
+	// from the cache.
+	eventModelEvicted = "model:evicted"
+	// eventModelUpdated carries the mesh ID and the *MeshDescriptor after an
+	// in-place change such as flipping its texture coordinates.
+	eventModelUpdated = "model:updated"
+	// eventModelHeavy carries the source path, the triangle count and the
+	// threshold it exceeds.
+	eventModelHeavy = "model:heavy"
+	// eventPrefsChanged carries the ViewerPrefs after they are saved or
+	// reset.
+	eventPrefsChanged = "prefs:changed"
+)
+
+// Events of loads started with LoadAsync. Each carries the task ID and the
+// source path first.
+const (
+	// eventLoadProgress adds a 0-100 percentage.
+	eventLoadProgress = "load:progress"
+	// eventLoadComplete adds the *MeshDescriptor of the decoded mesh.
+	eventLoadComplete = "load:complete"
+	// eventLoadError adds an error message.
+	eventLoadError = "load:error"
+	// eventLoadCancelled is sent instead of the others once CancelLoad
+	// succeeds.
+	eventLoadCancelled = "load:cancelled"
+)
+
+// Menu events with no payload, for actions the frontend carries out.
+const (
+	// eventMenuExport asks the frontend to export the current model.
+	eventMenuExport = "menu:export"
+	// eventViewResetCamera asks the viewer to refit the camera.
+	eventViewResetCamera = "view:resetCamera"
+	// eventViewToggleWireframe asks the viewer to toggle wireframe rendering.
+	eventViewToggleWireframe = "view:toggleWireframe"
+)
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ExportModel writes a loaded mesh to destPath. format is a registered
+// extension such as "obj", "stl", "ply" or "glb", or a variant listed by
+// SupportedFormats such as "ply-ascii"; when empty it is inferred from
+// destPath.
+func (a *App) ExportModel(meshID string, destPath string, format string) error {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+
+	if strings.EqualFold(filepath.Ext(destPath), ".gz") {
+		return fmt.Errorf("export: compressed output is not supported")
+	}
+	if write := exportVariantNamed(format); write != nil {
+		if err := write(m, destPath); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		return nil
+	}
+	target := formatForExtension(destPath)
+	if format != "" {
+		target = formatForExtension("." + strings.TrimPrefix(strings.ToLower(format), "."))
+	}
+	if target == nil {
+		return fmt.Errorf("export: unknown format %q", format)
+	}
+	if target.export == nil {
+		return fmt.Errorf("export: %s files cannot be written", target.name)
+	}
+	if err := target.export(m, destPath); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes path through a temporary file in the same directory
+// and renames it into place, so a failed or interrupted write never leaves a
+// partial file. Creating the temporary file also verifies the directory is
+// writable before any work is done.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("destination not writable: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	bw := bufio.NewWriterSize(tmp, 1<<16)
+	if err := write(bw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	// Flush to disk before the rename so a crash cannot leave the new name
+	// pointing at empty contents.
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// exportSTL writes binary STL with facet normals recomputed from winding.
+func exportSTL(m *Mesh, path string) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		var header [stlHeaderSize + 4]byte
+		copy(header[:], "Exported by Simple 3D Viewer")
+		binary.LittleEndian.PutUint32(header[stlHeaderSize:], uint32(m.TriangleCount()))
+		if _, err := w.Write(header[:]); err != nil {
+			return err
+		}
+
+		var tri [stlTriangleSize]byte
+		for t := 0; t < m.TriangleCount(); t++ {
+			p0, p1, p2 := m.triangle(t)
+			n := normalize(cross(sub(p1, p0), sub(p2, p0)))
+			for i, v := range [4][3]float64{n, p0, p1, p2} {
+				for c := 0; c < 3; c++ {
+					binary.LittleEndian.PutUint32(tri[i*12+c*4:], math.Float32bits(float32(v[c])))
+				}
+			}
+			var attr uint16
+			if t < len(m.Attributes) {
+				attr = m.Attributes[t]
+			}
+			binary.LittleEndian.PutUint16(tri[48:], attr)
+			if _, err := w.Write(tri[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// exportOBJ writes vertices, normals, UVs and faces, plus a sidecar .mtl
+// next to the OBJ when the mesh has materials.
+func exportOBJ(m *Mesh, path string) error {
+	return writeOBJ(m, path, false)
+}
+
+// exportColorOBJ writes OBJ with vertex colours as "v x y z r g b" lines.
+// Alpha has no place in that form and is dropped.
+func exportColorOBJ(m *Mesh, path string) error {
+	return writeOBJ(m, path, true)
+}
+
+func writeOBJ(m *Mesh, path string, withColors bool) error {
+	mtlName := ""
+	if len(m.Materials) > 0 {
+		mtlName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + ".mtl"
+		mtlPath := filepath.Join(filepath.Dir(path), mtlName)
+		if err := writeFileAtomic(mtlPath, func(w io.Writer) error {
+			return writeMTL(w, m.Materials, filepath.Dir(path))
+		}); err != nil {
+			return err
+		}
+	}
+
+	return writeFileAtomic(path, func(w io.Writer) error {
+		fmt.Fprintln(w, "# Exported by Simple 3D Viewer")
+		if mtlName != "" {
+			fmt.Fprintf(w, "mtllib %s\n", mtlName)
+		}
+		if withColors && m.hasVertexColors() {
+			var buf []byte
+			for v := 0; v < m.VertexCount(); v++ {
+				buf = append(buf[:0], "v "...)
+				buf = appendFloatRow(buf, m.Vertices[v*3:v*3+3])
+				buf = append(buf, ' ')
+				buf = appendFloatRow(buf, m.Colors[v*4:v*4+3])
+				buf = append(buf, '\n')
+				if _, err := w.Write(buf); err != nil {
+					return err
+				}
+			}
+		} else if err := writeOBJFloats(w, "v", m.Vertices, 3); err != nil {
+			return err
+		}
+		if err := writeOBJFloats(w, "vt", m.UVs, 2); err != nil {
+			return err
+		}
+		if err := writeOBJFloats(w, "vn", m.Normals, 3); err != nil {
+			return err
+		}
+		// Without groups the first material applies throughout; with them,
+		// each group is written as a g and usemtl pair before its faces.
+		if mtlName != "" && len(m.Groups) == 0 {
+			fmt.Fprintf(w, "usemtl %s\n", m.Materials[0].Name)
+		}
+
+		hasUV, hasNormal := len(m.UVs) > 0, len(m.Normals) > 0
+		var buf []byte
+		group := 0
+		for i := 0; i+2 < len(m.Indices); i += 3 {
+			buf = buf[:0]
+			for group < len(m.Groups) && m.Groups[group].Start+m.Groups[group].Count <= i {
+				group++
+				if group == len(m.Groups) || m.Groups[group].Start > i {
+					// Faces between groups return to the default group.
+					buf = append(buf, "g\n"...)
+				}
+			}
+			if group < len(m.Groups) && m.Groups[group].Start == i {
+				buf = appendOBJGroup(buf, m.Groups[group], m.Materials)
+			}
+			buf = append(buf, 'f')
+			for k := 0; k < 3; k++ {
+				idx := int64(m.Indices[i+k]) + 1
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, idx, 10)
+				switch {
+				case hasUV && hasNormal:
+					buf = append(buf, '/')
+					buf = strconv.AppendInt(buf, idx, 10)
+					buf = append(buf, '/')
+					buf = strconv.AppendInt(buf, idx, 10)
+				case hasUV:
+					buf = append(buf, '/')
+					buf = strconv.AppendInt(buf, idx, 10)
+				case hasNormal:
+					buf = append(buf, "//"...)
+					buf = strconv.AppendInt(buf, idx, 10)
+				}
+			}
+			buf = append(buf, '\n')
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+		}
+		for i := 0; i+1 < len(m.Lines); i += 2 {
+			if _, err := fmt.Fprintf(w, "l %d %d\n", m.Lines[i]+1, m.Lines[i+1]+1); err != nil {
+				return err
+			}
+		}
+		for _, v := range m.Points {
+			if _, err := fmt.Fprintf(w, "p %d\n", v+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// appendOBJGroup appends the g and usemtl lines that start a group.
+func appendOBJGroup(buf []byte, g SubMesh, materials []Material) []byte {
+	buf = append(buf, 'g')
+	if g.Name != "" && g.Name != objUnnamedGroup {
+		buf = append(buf, ' ')
+		buf = append(buf, g.Name...)
+	}
+	buf = append(buf, '\n')
+	if g.Material >= 0 && g.Material < len(materials) {
+		buf = append(buf, "usemtl "...)
+		buf = append(buf, materials[g.Material].Name...)
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// writeOBJFloats writes one "<keyword> a b c" line per n-component tuple.
+func writeOBJFloats(w io.Writer, keyword string, values []float32, n int) error {
+	var buf []byte
+	for i := 0; i+n <= len(values); i += n {
+		buf = append(buf[:0], keyword...)
+		buf = append(buf, ' ')
+		buf = appendFloatRow(buf, values[i:i+n])
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMTL writes materials with texture paths relative to dir when possible.
+func writeMTL(w io.Writer, materials []Material, dir string) error {
+	for _, mat := range materials {
+		fmt.Fprintf(w, "newmtl %s\n", mat.Name)
+		fmt.Fprintf(w, "Kd %g %g %g\n", mat.DiffuseColor[0], mat.DiffuseColor[1], mat.DiffuseColor[2])
+		fmt.Fprintf(w, "d %g\n", mat.Opacity)
+		if mat.DiffuseMap != "" {
+			fmt.Fprintf(w, "map_Kd %s\n", relativeAssetPath(dir, mat.DiffuseMap))
+		}
+		if mat.BumpMap != "" {
+			fmt.Fprintf(w, "map_Bump %s\n", relativeAssetPath(dir, mat.BumpMap))
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func relativeAssetPath(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// exportPLY writes a binary little-endian PLY with positions, plus normals
+// and vertex colours when present.
+func exportPLY(m *Mesh, path string) error {
+	return writePLY(m, path, true)
+}
+
+// exportASCIIPLY writes the same properties as exportPLY as text.
+func exportASCIIPLY(m *Mesh, path string) error {
+	return writePLY(m, path, false)
+}
+
+func writePLY(m *Mesh, path string, binaryBody bool) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		hasNormals := len(m.Normals) == len(m.Vertices)
+		hasColors := m.hasVertexColors()
+		// Alpha is only declared when some vertex is not opaque.
+		hasAlpha := false
+		for i := 3; hasColors && i < len(m.Colors); i += 4 {
+			hasAlpha = hasAlpha || m.Colors[i] < 1
+		}
+		channels := 3
+		if hasAlpha {
+			channels = 4
+		}
+
+		encoding := "ascii"
+		if binaryBody {
+			encoding = "binary_little_endian"
+		}
+		fmt.Fprintf(w, "ply\nformat %s 1.0\ncomment Exported by Simple 3D Viewer\n", encoding)
+		fmt.Fprintf(w, "element vertex %d\nproperty float x\nproperty float y\nproperty float z\n", m.VertexCount())
+		if hasNormals {
+			fmt.Fprintf(w, "property float nx\nproperty float ny\nproperty float nz\n")
+		}
+		if hasColors {
+			fmt.Fprintf(w, "property uchar red\nproperty uchar green\nproperty uchar blue\n")
+		}
+		if hasAlpha {
+			fmt.Fprintf(w, "property uchar alpha\n")
+		}
+		fmt.Fprintf(w, "element face %d\nproperty list uchar int vertex_indices\nend_header\n", m.TriangleCount())
+
+		var buf []byte
+		for v := 0; v < m.VertexCount(); v++ {
+			buf = buf[:0]
+			if binaryBody {
+				for _, f := range m.Vertices[v*3 : v*3+3] {
+					buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(f))
+				}
+				if hasNormals {
+					for _, f := range m.Normals[v*3 : v*3+3] {
+						buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(f))
+					}
+				}
+				if hasColors {
+					for _, c := range m.Colors[v*4 : v*4+channels] {
+						buf = append(buf, colorByte(c))
+					}
+				}
+			} else {
+				buf = appendFloatRow(buf, m.Vertices[v*3:v*3+3])
+				if hasNormals {
+					buf = append(buf, ' ')
+					buf = appendFloatRow(buf, m.Normals[v*3:v*3+3])
+				}
+				if hasColors {
+					for _, c := range m.Colors[v*4 : v*4+channels] {
+						buf = append(buf, ' ')
+						buf = strconv.AppendUint(buf, uint64(colorByte(c)), 10)
+					}
+				}
+				buf = append(buf, '\n')
+			}
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+		}
+		for i := 0; i+2 < len(m.Indices); i += 3 {
+			buf = buf[:0]
+			if binaryBody {
+				buf = append(buf, 3)
+				for k := 0; k < 3; k++ {
+					buf = binary.LittleEndian.AppendUint32(buf, m.Indices[i+k])
+				}
+			} else {
+				buf = append(buf, '3')
+				for k := 0; k < 3; k++ {
+					buf = append(buf, ' ')
+					buf = strconv.AppendUint(buf, uint64(m.Indices[i+k]), 10)
+				}
+				buf = append(buf, '\n')
+			}
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// colorByte converts a [0,1] channel to 0-255, clamping out-of-range values.
+func colorByte(c float32) byte {
+	return byte(math.Round(float64(min(max(c, 0), 1)) * 255))
+}
+
+func appendFloatRow(buf []byte, values []float32) []byte {
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+	}
+	return buf
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportOBJKeepsGroupMaterials(t *testing.T) {
+	src := &Mesh{
+		Vertices: []float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0, 2, 0, 0},
+		Indices:  []uint32{0, 1, 2, 0, 2, 3, 1, 4, 2},
+		Materials: []Material{
+			{Name: "red", DiffuseColor: [3]float32{1, 0, 0}, Opacity: 1},
+			{Name: "blue", DiffuseColor: [3]float32{0, 0, 1}, Opacity: 1},
+		},
+		Groups: []SubMesh{
+			{Name: "top", Start: 0, Count: 3, Material: 1},
+			{Name: "bottom", Start: 3, Count: 3, Material: 0},
+		},
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.obj")
+	if err := exportOBJ(src, path); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	m, err := parseOBJ(f, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name     string
+		material string
+	}{{"top", "blue"}, {"bottom", "red"}, {objUnnamedGroup, "red"}}
+	if len(m.Groups) != len(want) {
+		t.Fatalf("groups = %+v, want %d", m.Groups, len(want))
+	}
+	for i, g := range m.Groups {
+		if g.Name != want[i].name || g.Count != 3 || g.Material < 0 || m.Materials[g.Material].Name != want[i].material {
+			t.Errorf("group %d = %+v, want %s with %s", i, g, want[i].name, want[i].material)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// modelFormat describes one file type. The registry below is the single
+// source of truth for dialog filters, load dispatch and export. export is nil
+// for formats that can only be read, and peek is nil for formats that must be
+// loaded in full to be summarised. colors, uvs and materials record what the
+// loader reads from the file.
+type modelFormat struct {
+	name       string
+	extensions []string
+	load       func(ctx context.Context, path string, progress progressFunc) (*Mesh, error)
+	export     func(m *Mesh, path string) error
+	peek       func(path string) (*Peek, error)
+	// variants are alternative writers selected by name in ExportModel.
+	variants []exportVariant
+
+	colors, uvs, materials bool
+}
+
+// exportVariant is a named writer other than a format's default one.
+type exportVariant struct {
+	name  string
+	write func(m *Mesh, path string) error
+}
+
+var modelFormats = []modelFormat{
+	{
+		name:       "Wavefront OBJ",
+		extensions: []string{".obj"},
+		load:       loadOBJ,
+		export:     exportOBJ,
+		peek:       peekOBJ,
+		// obj-colors appends RGB to each vertex line, a widespread but
+		// non-standard OBJ extension.
+		variants:  []exportVariant{{"obj-colors", exportColorOBJ}},
+		uvs:       true,
+		materials: true,
+	},
+	{
+		name:       "STL",
+		extensions: []string{".stl"},
+		load:       loadSTL,
+		export:     exportSTL,
+		peek:       peekSTL,
+	},
+	{
+		name:       "glTF 2.0",
+		extensions: []string{".gltf", ".glb"},
+		load:       loadGLTFMesh,
+		export:     exportGLB,
+		uvs:        true,
+		materials:  true,
+	},
+	{
+		name:       "Stanford PLY",
+		extensions: []string{".ply"},
+		load:       loadPLY,
+		export:     exportPLY,
+		peek:       peekPLY,
+		// ply-ascii writes text PLY instead of the smaller binary default.
+		variants: []exportVariant{{"ply-ascii", exportASCIIPLY}},
+		colors:   true,
+		uvs:      true,
+	},
+	{
+		name:       "3D Manufacturing Format",
+		extensions: []string{".3mf"},
+		load:       load3MFMesh,
+		materials:  true,
+	},
+	{
+		name:       "Object File Format",
+		extensions: []string{".off"},
+		load:       loadOFF,
+		peek:       peekOFF,
+		colors:     true,
+		uvs:        true,
+	},
+}
+
+// FormatInfo describes a registered format to the frontend.
+type FormatInfo struct {
+	Name string `json:"name"`
+	// Extensions include the leading dot, such as ".obj".
+	Extensions []string `json:"extensions"`
+	CanLoad    bool     `json:"canLoad"`
+	CanExport  bool     `json:"canExport"`
+	// ExportVariants are extra format names accepted by ExportModel.
+	ExportVariants []string `json:"exportVariants"`
+	// Colors, UVs and Materials report what loading a file of this format
+	// can produce.
+	Colors    bool `json:"colors"`
+	UVs       bool `json:"uvs"`
+	Materials bool `json:"materials"`
+}
+
+// SupportedFormats lists the registered formats in registry order, for
+// building open filters and export choices.
+func (a *App) SupportedFormats() []FormatInfo {
+	return supportedFormats()
+}
+
+func supportedFormats() []FormatInfo {
+	infos := make([]FormatInfo, 0, len(modelFormats))
+	for _, f := range modelFormats {
+		info := FormatInfo{
+			Name:           f.name,
+			Extensions:     slices.Clone(f.extensions),
+			CanLoad:        f.load != nil,
+			CanExport:      f.export != nil,
+			ExportVariants: []string{},
+			Colors:         f.colors,
+			UVs:            f.uvs,
+			Materials:      f.materials,
+		}
+		for _, v := range f.variants {
+			info.ExportVariants = append(info.ExportVariants, v.name)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// exportVariantNamed returns the writer registered under a variant name, or
+// nil.
+func exportVariantNamed(name string) func(m *Mesh, path string) error {
+	for _, f := range modelFormats {
+		for _, v := range f.variants {
+			if strings.EqualFold(v.name, name) {
+				return v.write
+			}
+		}
+	}
+	return nil
+}
+
+// formatForPath looks up a format by file extension, falling back to
+// sniffing the leading bytes for files with missing or unusual extensions.
+// Gzip-compressed files are sniffed by their inflated contents.
+func formatForPath(path string) (*modelFormat, error) {
+	if format := formatForExtension(path); format != nil {
+		return format, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if gzipped(f) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), corruptGzip(err))
+		}
+		r = zr
+	}
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(r, head)
+	if format := formatForExtension(sniffExtension(head[:n])); format != nil {
+		return format, nil
+	}
+	return nil, fmt.Errorf("unsupported file type %q", filepath.Base(path))
+}
+
+// formatForExtension returns the format registered for path's extension, or
+// nil. A trailing .gz is looked through.
+func formatForExtension(path string) *modelFormat {
+	ext := modelExtension(path)
+	for i := range modelFormats {
+		for _, e := range modelFormats[i].extensions {
+			if e == ext {
+				return &modelFormats[i]
+			}
+		}
+	}
+	return nil
+}
+
+// sniffExtension guesses a registered extension from file contents.
+func sniffExtension(head []byte) string {
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	switch {
+	case len(head) >= 4 && binary.LittleEndian.Uint32(head) == glbMagic:
+		return ".glb"
+	case bytes.HasPrefix(trimmed, []byte("{")) && bytes.Contains(head, []byte(`"asset"`)):
+		return ".gltf"
+	case bytes.HasPrefix(head, []byte("ply\n")), bytes.HasPrefix(head, []byte("ply\r\n")):
+		return ".ply"
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		// 3MF is the only zip-based format supported.
+		return ".3mf"
+	case bytes.HasPrefix(trimmed, []byte("OFF")), bytes.HasPrefix(trimmed, []byte("COFF")),
+		bytes.HasPrefix(trimmed, []byte("NOFF")), bytes.HasPrefix(trimmed, []byte("STOFF")):
+		return ".off"
+	case bytes.HasPrefix(trimmed, []byte("solid")):
+		return ".stl"
+	case bytes.HasPrefix(trimmed, []byte("v ")), bytes.HasPrefix(trimmed, []byte("#")),
+		bytes.HasPrefix(trimmed, []byte("mtllib")), bytes.HasPrefix(trimmed, []byte("o ")):
+		return ".obj"
+	}
+	return ""
+}
+
+// loadModel dispatches path to the matching loader. Cancelling ctx stops
+// the loader at its next read.
+func loadModel(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	format, err := formatForPath(path)
+	if err != nil {
+		appLog.error("model load failed", "path", path, "error", err)
+		return nil, err
+	}
+	return loadFormat(ctx, format, path, progress)
+}
+
+// loadFormat parses path as format, recording file metadata and timing.
+func loadFormat(ctx context.Context, format *modelFormat, path string, progress progressFunc) (*Mesh, error) {
+	start := time.Now()
+	ctx, hash := withContentHash(ctx, path)
+	mesh, err := format.load(ctx, path, progress)
+	if ctx.Err() != nil {
+		// Whatever the loader made of the aborted read is dropped here, so
+		// partial buffers are left for the garbage collector.
+		appLog.info("model load cancelled", "path", path, "format", format.name)
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), ctx.Err())
+	}
+	compressed := isGzipFile(path)
+	if err != nil {
+		if compressed {
+			if gzErr := verifyGzip(path); gzErr != nil {
+				err = fmt.Errorf("%s: %w", filepath.Base(path), gzErr)
+			}
+		}
+		appLog.error("model load failed", "path", path, "format", format.name, "error", err)
+		return nil, err
+	}
+	elapsed := time.Since(start)
+	name := format.name
+	if compressed {
+		name += " (gzip)"
+	}
+	mesh.Meta = describeFile(mesh.Meta, path, name, elapsed)
+	mesh.Meta.Hash = hash.sum()
+	appLog.info("model loaded", "path", path, "format", format.name,
+		"triangles", mesh.TriangleCount(), "vertices", mesh.VertexCount(), "duration", elapsed)
+	if n := mesh.Meta.SkippedRecords; n > 0 {
+		appLog.warn("malformed records skipped", "path", path, "count", n, "first", mesh.Meta.Warnings[0])
+	}
+	return mesh, nil
+}
+
+func loadGLTFMesh(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	scene, err := loadGLTF(ctx, path, progress)
+	if err != nil {
+		return nil, err
+	}
+	mesh := scene.flatten()
+	mesh.Units = "m"
+	mesh.Meta = scene.Meta
+	return mesh, nil
+}
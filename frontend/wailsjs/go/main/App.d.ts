@@ -1,4 +1,113 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+
+export function ApplyTransform(arg1:string,arg2:any):Promise<main.Mesh>;
+
+export function CancelLoad(arg1:string):Promise<void>;
+
+export function CenterModel(arg1:string,arg2:boolean):Promise<main.Transform>;
+
+export function ClearRecentFiles():Promise<void>;
+
+export function ClearTransform(arg1:string):Promise<void>;
+
+export function ComputeScalarField(arg1:string,arg2:string):Promise<main.ScalarField>;
+
+export function ComputeStats(arg1:string):Promise<main.MeshStats>;
+
+export function ConvertUnits(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DeleteView(arg1:string,arg2:string):Promise<void>;
+
+export function EnableAutoReload(arg1:string,arg2:boolean):Promise<void>;
+
+export function ExportModel(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GenerateThumbnail(arg1:string,arg2:number):Promise<Array<number>>;
+
+export function GetCameraFit(arg1:string,arg2:number,arg3:number):Promise<main.CameraPose>;
+
+export function GetDefaultUnits():Promise<string>;
+
+export function GetHeavyThreshold():Promise<number>;
+
+export function GetLogPath():Promise<string>;
+
+export function GetModelHash(arg1:string):Promise<string>;
+
+export function GetRecentFiles():Promise<Array<main.RecentFile>>;
+
+export function GetVersionInfo():Promise<main.VersionInfo>;
+
+export function GetViewerPrefs():Promise<main.ViewerPrefs>;
+
+export function GetViews(arg1:string):Promise<Array<main.NamedView>>;
 
 export function Greet(arg1:string):Promise<string>;
+
+export function ListLoadedModels():Promise<Array<main.ModelInfo>>;
+
+export function Load3MF(arg1:string):Promise<main.Scene>;
+
+export function LoadAsync(arg1:string):Promise<string>;
+
+export function LoadGLTF(arg1:string):Promise<main.Scene>;
+
+export function LoadModel(arg1:string):Promise<main.MeshDescriptor>;
+
+export function LoadModelAsync(arg1:string):Promise<void>;
+
+export function LoadMultiple(arg1:Array<string>):Promise<main.Scene>;
+
+export function LoadOBJ(arg1:string):Promise<main.Mesh>;
+
+export function LoadOFF(arg1:string):Promise<main.Mesh>;
+
+export function LoadPLY(arg1:string):Promise<main.Mesh>;
+
+export function LoadSTL(arg1:string):Promise<main.Mesh>;
+
+export function OpenModelDialog():Promise<main.MeshDescriptor>;
+
+export function PeekModel(arg1:string):Promise<main.Peek>;
+
+export function RecomputeNormals(arg1:string,arg2:boolean,arg3:boolean):Promise<void>;
+
+export function ReloadModel(arg1:string):Promise<main.Mesh>;
+
+export function RepairMesh(arg1:string,arg2:number):Promise<main.RepairResult>;
+
+export function ResetSettings(arg1:boolean):Promise<void>;
+
+export function SaveScreenshot(arg1:Array<number>,arg2:string):Promise<string>;
+
+export function SaveView(arg1:string,arg2:string,arg3:main.CameraPose):Promise<void>;
+
+export function SetCacheLimit(arg1:number):Promise<void>;
+
+export function SetDefaultUnits(arg1:string):Promise<void>;
+
+export function SetFlipV(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetHeavyThreshold(arg1:number):Promise<void>;
+
+export function SetModelUnits(arg1:string,arg2:string):Promise<void>;
+
+export function SetViewerPrefs(arg1:main.ViewerPrefs):Promise<void>;
+
+export function Simplify(arg1:string,arg2:number):Promise<main.Mesh>;
+
+export function SlicePlane(arg1:string,arg2:any,arg3:any):Promise<Array<main.Polyline>>;
+
+export function SplitComponents(arg1:string,arg2:number):Promise<main.SplitResult>;
+
+export function SupportedFormats():Promise<Array<main.FormatInfo>>;
+
+export function UnifyWinding(arg1:string):Promise<main.WindingResult>;
+
+export function UnloadModel(arg1:string):Promise<void>;
+
+export function ValidateMesh(arg1:string):Promise<main.ValidationReport>;
+
+export function WeldVertices(arg1:string,arg2:number,arg3:boolean):Promise<main.WeldResult>;
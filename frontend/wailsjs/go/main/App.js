@@ -2,6 +2,222 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ApplyTransform(arg1, arg2) {
+  return window['go']['main']['App']['ApplyTransform'](arg1, arg2);
+}
+
+export function CancelLoad(arg1) {
+  return window['go']['main']['App']['CancelLoad'](arg1);
+}
+
+export function CenterModel(arg1, arg2) {
+  return window['go']['main']['App']['CenterModel'](arg1, arg2);
+}
+
+export function ClearRecentFiles() {
+  return window['go']['main']['App']['ClearRecentFiles']();
+}
+
+export function ClearTransform(arg1) {
+  return window['go']['main']['App']['ClearTransform'](arg1);
+}
+
+export function ComputeScalarField(arg1, arg2) {
+  return window['go']['main']['App']['ComputeScalarField'](arg1, arg2);
+}
+
+export function ComputeStats(arg1) {
+  return window['go']['main']['App']['ComputeStats'](arg1);
+}
+
+export function ConvertUnits(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ConvertUnits'](arg1, arg2, arg3);
+}
+
+export function DeleteView(arg1, arg2) {
+  return window['go']['main']['App']['DeleteView'](arg1, arg2);
+}
+
+export function EnableAutoReload(arg1, arg2) {
+  return window['go']['main']['App']['EnableAutoReload'](arg1, arg2);
+}
+
+export function ExportModel(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportModel'](arg1, arg2, arg3);
+}
+
+export function GenerateThumbnail(arg1, arg2) {
+  return window['go']['main']['App']['GenerateThumbnail'](arg1, arg2);
+}
+
+export function GetCameraFit(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetCameraFit'](arg1, arg2, arg3);
+}
+
+export function GetDefaultUnits() {
+  return window['go']['main']['App']['GetDefaultUnits']();
+}
+
+export function GetHeavyThreshold() {
+  return window['go']['main']['App']['GetHeavyThreshold']();
+}
+
+export function GetLogPath() {
+  return window['go']['main']['App']['GetLogPath']();
+}
+
+export function GetModelHash(arg1) {
+  return window['go']['main']['App']['GetModelHash'](arg1);
+}
+
+export function GetRecentFiles() {
+  return window['go']['main']['App']['GetRecentFiles']();
+}
+
+export function GetVersionInfo() {
+  return window['go']['main']['App']['GetVersionInfo']();
+}
+
+export function GetViewerPrefs() {
+  return window['go']['main']['App']['GetViewerPrefs']();
+}
+
+export function GetViews(arg1) {
+  return window['go']['main']['App']['GetViews'](arg1);
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
+
+export function ListLoadedModels() {
+  return window['go']['main']['App']['ListLoadedModels']();
+}
+
+export function Load3MF(arg1) {
+  return window['go']['main']['App']['Load3MF'](arg1);
+}
+
+export function LoadAsync(arg1) {
+  return window['go']['main']['App']['LoadAsync'](arg1);
+}
+
+export function LoadGLTF(arg1) {
+  return window['go']['main']['App']['LoadGLTF'](arg1);
+}
+
+export function LoadModel(arg1) {
+  return window['go']['main']['App']['LoadModel'](arg1);
+}
+
+export function LoadModelAsync(arg1) {
+  return window['go']['main']['App']['LoadModelAsync'](arg1);
+}
+
+export function LoadMultiple(arg1) {
+  return window['go']['main']['App']['LoadMultiple'](arg1);
+}
+
+export function LoadOBJ(arg1) {
+  return window['go']['main']['App']['LoadOBJ'](arg1);
+}
+
+export function LoadOFF(arg1) {
+  return window['go']['main']['App']['LoadOFF'](arg1);
+}
+
+export function LoadPLY(arg1) {
+  return window['go']['main']['App']['LoadPLY'](arg1);
+}
+
+export function LoadSTL(arg1) {
+  return window['go']['main']['App']['LoadSTL'](arg1);
+}
+
+export function OpenModelDialog() {
+  return window['go']['main']['App']['OpenModelDialog']();
+}
+
+export function PeekModel(arg1) {
+  return window['go']['main']['App']['PeekModel'](arg1);
+}
+
+export function RecomputeNormals(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RecomputeNormals'](arg1, arg2, arg3);
+}
+
+export function ReloadModel(arg1) {
+  return window['go']['main']['App']['ReloadModel'](arg1);
+}
+
+export function RepairMesh(arg1, arg2) {
+  return window['go']['main']['App']['RepairMesh'](arg1, arg2);
+}
+
+export function ResetSettings(arg1) {
+  return window['go']['main']['App']['ResetSettings'](arg1);
+}
+
+export function SaveScreenshot(arg1, arg2) {
+  return window['go']['main']['App']['SaveScreenshot'](arg1, arg2);
+}
+
+export function SaveView(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveView'](arg1, arg2, arg3);
+}
+
+export function SetCacheLimit(arg1) {
+  return window['go']['main']['App']['SetCacheLimit'](arg1);
+}
+
+export function SetDefaultUnits(arg1) {
+  return window['go']['main']['App']['SetDefaultUnits'](arg1);
+}
+
+export function SetFlipV(arg1, arg2) {
+  return window['go']['main']['App']['SetFlipV'](arg1, arg2);
+}
+
+export function SetHeavyThreshold(arg1) {
+  return window['go']['main']['App']['SetHeavyThreshold'](arg1);
+}
+
+export function SetModelUnits(arg1, arg2) {
+  return window['go']['main']['App']['SetModelUnits'](arg1, arg2);
+}
+
+export function SetViewerPrefs(arg1) {
+  return window['go']['main']['App']['SetViewerPrefs'](arg1);
+}
+
+export function Simplify(arg1, arg2) {
+  return window['go']['main']['App']['Simplify'](arg1, arg2);
+}
+
+export function SlicePlane(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SlicePlane'](arg1, arg2, arg3);
+}
+
+export function SplitComponents(arg1, arg2) {
+  return window['go']['main']['App']['SplitComponents'](arg1, arg2);
+}
+
+export function SupportedFormats() {
+  return window['go']['main']['App']['SupportedFormats']();
+}
+
+export function UnifyWinding(arg1) {
+  return window['go']['main']['App']['UnifyWinding'](arg1);
+}
+
+export function UnloadModel(arg1) {
+  return window['go']['main']['App']['UnloadModel'](arg1);
+}
+
+export function ValidateMesh(arg1) {
+  return window['go']['main']['App']['ValidateMesh'](arg1);
+}
+
+export function WeldVertices(arg1, arg2, arg3) {
+  return window['go']['main']['App']['WeldVertices'](arg1, arg2, arg3);
+}
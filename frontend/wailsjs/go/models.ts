@@ -0,0 +1,954 @@
+export namespace main {
+	
+	export class Bounds {
+	    min: number[];
+	    max: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Bounds(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.min = source["min"];
+	        this.max = source["max"];
+	    }
+	}
+	export class BufferDescriptor {
+	    name: string;
+	    url: string;
+	    componentType: string;
+	    components: number;
+	    count: number;
+	    stride: number;
+	    byteLength: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BufferDescriptor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	        this.componentType = source["componentType"];
+	        this.components = source["components"];
+	        this.count = source["count"];
+	        this.stride = source["stride"];
+	        this.byteLength = source["byteLength"];
+	    }
+	}
+	export class CameraPose {
+	    target: number[];
+	    eye: number[];
+	    up: number[];
+	    near: number;
+	    far: number;
+	    radius: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CameraPose(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.target = source["target"];
+	        this.eye = source["eye"];
+	        this.up = source["up"];
+	        this.near = source["near"];
+	        this.far = source["far"];
+	        this.radius = source["radius"];
+	    }
+	}
+	export class FileError {
+	    path: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.error = source["error"];
+	    }
+	}
+	export class FormatInfo {
+	    name: string;
+	    extensions: string[];
+	    canLoad: boolean;
+	    canExport: boolean;
+	    exportVariants: string[];
+	    colors: boolean;
+	    uvs: boolean;
+	    materials: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormatInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.extensions = source["extensions"];
+	        this.canLoad = source["canLoad"];
+	        this.canExport = source["canExport"];
+	        this.exportVariants = source["exportVariants"];
+	        this.colors = source["colors"];
+	        this.uvs = source["uvs"];
+	        this.materials = source["materials"];
+	    }
+	}
+	export class Material {
+	    name: string;
+	    diffuseColor: number[];
+	    opacity: number;
+	    diffuseMap?: string;
+	    bumpMap?: string;
+	    metallic?: number;
+	    roughness?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Material(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.diffuseColor = source["diffuseColor"];
+	        this.opacity = source["opacity"];
+	        this.diffuseMap = source["diffuseMap"];
+	        this.bumpMap = source["bumpMap"];
+	        this.metallic = source["metallic"];
+	        this.roughness = source["roughness"];
+	    }
+	}
+	export class SubMesh {
+	    name: string;
+	    start: number;
+	    count: number;
+	    material: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubMesh(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.start = source["start"];
+	        this.count = source["count"];
+	        this.material = source["material"];
+	    }
+	}
+	export class ModelMeta {
+	    path: string;
+	    size: number;
+	    format: string;
+	    parseMs: number;
+	    hash?: string;
+	    header?: string;
+	    comments?: string[];
+	    skippedRecords?: number;
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelMeta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.format = source["format"];
+	        this.parseMs = source["parseMs"];
+	        this.hash = source["hash"];
+	        this.header = source["header"];
+	        this.comments = source["comments"];
+	        this.skippedRecords = source["skippedRecords"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class Transform {
+	    translation: number[];
+	    matrix: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Transform(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.translation = source["translation"];
+	        this.matrix = source["matrix"];
+	    }
+	}
+	export class Mesh {
+	    id: string;
+	    vertices: number[];
+	    normals: number[];
+	    uvs: number[];
+	    indices: number[];
+	    materials: Material[];
+	    lines?: number[];
+	    points?: number[];
+	    colors?: number[];
+	    pointCloud?: boolean;
+	    flipV?: boolean;
+	    transform?: Transform;
+	    units: string;
+	    meta?: ModelMeta;
+	    groups?: SubMesh[];
+	    attributes?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Mesh(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.vertices = source["vertices"];
+	        this.normals = source["normals"];
+	        this.uvs = source["uvs"];
+	        this.indices = source["indices"];
+	        this.materials = this.convertValues(source["materials"], Material);
+	        this.lines = source["lines"];
+	        this.points = source["points"];
+	        this.colors = source["colors"];
+	        this.pointCloud = source["pointCloud"];
+	        this.flipV = source["flipV"];
+	        this.transform = this.convertValues(source["transform"], Transform);
+	        this.units = source["units"];
+	        this.meta = this.convertValues(source["meta"], ModelMeta);
+	        this.groups = this.convertValues(source["groups"], SubMesh);
+	        this.attributes = source["attributes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MeshComponent {
+	    id: string;
+	    triangleCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeshComponent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.triangleCount = source["triangleCount"];
+	    }
+	}
+	export class MeshDescriptor {
+	    id: string;
+	    vertexCount: number;
+	    triangleCount: number;
+	    min: number[];
+	    max: number[];
+	    pointCloud?: boolean;
+	    materials: Material[];
+	    groups?: SubMesh[];
+	    buffers: BufferDescriptor[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MeshDescriptor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.vertexCount = source["vertexCount"];
+	        this.triangleCount = source["triangleCount"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.pointCloud = source["pointCloud"];
+	        this.materials = this.convertValues(source["materials"], Material);
+	        this.groups = this.convertValues(source["groups"], SubMesh);
+	        this.buffers = this.convertValues(source["buffers"], BufferDescriptor);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MeshStats {
+	    min: number[];
+	    max: number[];
+	    dimensions: number[];
+	    centroid: number[];
+	    triangleCount: number;
+	    vertexCount: number;
+	    surfaceArea: number;
+	    volume: number;
+	    closed: boolean;
+	    units: string;
+	    flipV: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeshStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.dimensions = source["dimensions"];
+	        this.centroid = source["centroid"];
+	        this.triangleCount = source["triangleCount"];
+	        this.vertexCount = source["vertexCount"];
+	        this.surfaceArea = source["surfaceArea"];
+	        this.volume = source["volume"];
+	        this.closed = source["closed"];
+	        this.units = source["units"];
+	        this.flipV = source["flipV"];
+	    }
+	}
+	export class ModelInfo {
+	    id: string;
+	    path: string;
+	    name: string;
+	    vertexCount: number;
+	    triangleCount: number;
+	    bytes: number;
+	    lastUsed: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.vertexCount = source["vertexCount"];
+	        this.triangleCount = source["triangleCount"];
+	        this.bytes = source["bytes"];
+	        this.lastUsed = source["lastUsed"];
+	    }
+	}
+	
+	export class NamedView {
+	    name: string;
+	    pose: CameraPose;
+	    savedAt: string;
+	    computed?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NamedView(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pose = this.convertValues(source["pose"], CameraPose);
+	        this.savedAt = source["savedAt"];
+	        this.computed = source["computed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PBRMaterial {
+	    name: string;
+	    baseColorFactor: number[];
+	    metallicFactor: number;
+	    roughnessFactor: number;
+	    emissiveFactor: number[];
+	    baseColorTexture: number;
+	    metallicRoughnessTexture: number;
+	    normalTexture: number;
+	    occlusionTexture: number;
+	    emissiveTexture: number;
+	    alphaMode: string;
+	    doubleSided: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PBRMaterial(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.baseColorFactor = source["baseColorFactor"];
+	        this.metallicFactor = source["metallicFactor"];
+	        this.roughnessFactor = source["roughnessFactor"];
+	        this.emissiveFactor = source["emissiveFactor"];
+	        this.baseColorTexture = source["baseColorTexture"];
+	        this.metallicRoughnessTexture = source["metallicRoughnessTexture"];
+	        this.normalTexture = source["normalTexture"];
+	        this.occlusionTexture = source["occlusionTexture"];
+	        this.emissiveTexture = source["emissiveTexture"];
+	        this.alphaMode = source["alphaMode"];
+	        this.doubleSided = source["doubleSided"];
+	    }
+	}
+	export class Peek {
+	    format: string;
+	    min: number[];
+	    max: number[];
+	    vertexCount: number;
+	    triangleCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Peek(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.vertexCount = source["vertexCount"];
+	        this.triangleCount = source["triangleCount"];
+	    }
+	}
+	export class Polyline {
+	    points: number[][];
+	    closed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Polyline(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.points = source["points"];
+	        this.closed = source["closed"];
+	    }
+	}
+	export class Primitive {
+	    mesh?: Mesh;
+	    material: number;
+	    mode: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Primitive(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mesh = this.convertValues(source["mesh"], Mesh);
+	        this.material = source["material"];
+	        this.mode = source["mode"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProblemEdge {
+	    a: number;
+	    b: number;
+	    faces: number;
+	    kind: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProblemEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.a = source["a"];
+	        this.b = source["b"];
+	        this.faces = source["faces"];
+	        this.kind = source["kind"];
+	    }
+	}
+	export class Range {
+	    min: number;
+	    max: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Range(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.min = source["min"];
+	        this.max = source["max"];
+	    }
+	}
+	export class RecentFile {
+	    path: string;
+	    name: string;
+	    lastOpened: string;
+	    size: number;
+	    hash?: string;
+	    missing: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.lastOpened = source["lastOpened"];
+	        this.size = source["size"];
+	        this.hash = source["hash"];
+	        this.missing = source["missing"];
+	    }
+	}
+	export class RepairResult {
+	    mesh?: Mesh;
+	    weldedVertices: number;
+	    degenerateTriangles: number;
+	    duplicateTriangles: number;
+	    orphanVertices: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepairResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mesh = this.convertValues(source["mesh"], Mesh);
+	        this.weldedVertices = source["weldedVertices"];
+	        this.degenerateTriangles = source["degenerateTriangles"];
+	        this.duplicateTriangles = source["duplicateTriangles"];
+	        this.orphanVertices = source["orphanVertices"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScalarField {
+	    field: string;
+	    domain: string;
+	    values: number[];
+	    range: Range;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScalarField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.domain = source["domain"];
+	        this.values = source["values"];
+	        this.range = this.convertValues(source["range"], Range);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SceneImage {
+	    name: string;
+	    mimeType: string;
+	    path?: string;
+	    data?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SceneImage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.mimeType = source["mimeType"];
+	        this.path = source["path"];
+	        this.data = source["data"];
+	    }
+	}
+	export class SceneTexture {
+	    image: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SceneTexture(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image = source["image"];
+	    }
+	}
+	export class SceneMesh {
+	    name: string;
+	    primitives: Primitive[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SceneMesh(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.primitives = this.convertValues(source["primitives"], Primitive);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SceneNode {
+	    name: string;
+	    children: number[];
+	    mesh: number;
+	    translation: number[];
+	    rotation: number[];
+	    scale: number[];
+	    matrix?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SceneNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.children = source["children"];
+	        this.mesh = source["mesh"];
+	        this.translation = source["translation"];
+	        this.rotation = source["rotation"];
+	        this.scale = source["scale"];
+	        this.matrix = source["matrix"];
+	    }
+	}
+	export class Scene {
+	    nodes: SceneNode[];
+	    roots: number[];
+	    meshes: SceneMesh[];
+	    materials: PBRMaterial[];
+	    textures: SceneTexture[];
+	    images: SceneImage[];
+	    meta?: ModelMeta;
+	    bounds?: Bounds;
+	    errors?: FileError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Scene(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodes = this.convertValues(source["nodes"], SceneNode);
+	        this.roots = source["roots"];
+	        this.meshes = this.convertValues(source["meshes"], SceneMesh);
+	        this.materials = this.convertValues(source["materials"], PBRMaterial);
+	        this.textures = this.convertValues(source["textures"], SceneTexture);
+	        this.images = this.convertValues(source["images"], SceneImage);
+	        this.meta = this.convertValues(source["meta"], ModelMeta);
+	        this.bounds = this.convertValues(source["bounds"], Bounds);
+	        this.errors = this.convertValues(source["errors"], FileError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class SplitResult {
+	    components: MeshComponent[];
+	    discarded: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SplitResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.components = this.convertValues(source["components"], MeshComponent);
+	        this.discarded = source["discarded"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ValidationReport {
+	    watertight: boolean;
+	    nonManifoldEdges: number;
+	    boundaryEdges: number;
+	    duplicateVertices: number;
+	    seamVertices: number;
+	    degenerateTriangles: number;
+	    isolatedVertices: number;
+	    problemEdges: ProblemEdge[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ValidationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.watertight = source["watertight"];
+	        this.nonManifoldEdges = source["nonManifoldEdges"];
+	        this.boundaryEdges = source["boundaryEdges"];
+	        this.duplicateVertices = source["duplicateVertices"];
+	        this.seamVertices = source["seamVertices"];
+	        this.degenerateTriangles = source["degenerateTriangles"];
+	        this.isolatedVertices = source["isolatedVertices"];
+	        this.problemEdges = this.convertValues(source["problemEdges"], ProblemEdge);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VersionInfo {
+	    version: string;
+	    gitCommit: string;
+	    buildDate: string;
+	    goVersion: string;
+	    os: string;
+	    arch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.gitCommit = source["gitCommit"];
+	        this.buildDate = source["buildDate"];
+	        this.goVersion = source["goVersion"];
+	        this.os = source["os"];
+	        this.arch = source["arch"];
+	    }
+	}
+	export class ViewerPrefs {
+	    background: string;
+	    grid: boolean;
+	    gridColor: string;
+	    upAxis: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ViewerPrefs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.background = source["background"];
+	        this.grid = source["grid"];
+	        this.gridColor = source["gridColor"];
+	        this.upAxis = source["upAxis"];
+	    }
+	}
+	export class WeldResult {
+	    mesh?: Mesh;
+	    verticesBefore: number;
+	    verticesAfter: number;
+	    tolerance: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeldResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mesh = this.convertValues(source["mesh"], Mesh);
+	        this.verticesBefore = source["verticesBefore"];
+	        this.verticesAfter = source["verticesAfter"];
+	        this.tolerance = source["tolerance"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WindingResult {
+	    mesh?: Mesh;
+	    flipped: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mesh = this.convertValues(source["mesh"], Mesh);
+	        this.flipped = source["flipped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
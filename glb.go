@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// glTF buffer view targets.
+const (
+	gltfArrayBuffer        = 34962
+	gltfElementArrayBuffer = 34963
+)
+
+// glbMimeTypes lists the image types glTF allows, by file extension.
+var glbMimeTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+}
+
+// The glb* types mirror the gltf* reader types with the omitempty tags a
+// valid document needs; the reader's would write nulls and empty URIs.
+type glbDocument struct {
+	Asset struct {
+		Version   string `json:"version"`
+		Generator string `json:"generator"`
+	} `json:"asset"`
+	Scene       int             `json:"scene"`
+	Scenes      []gltfScene     `json:"scenes"`
+	Nodes       []glbNode       `json:"nodes"`
+	Meshes      []glbMesh       `json:"meshes"`
+	Materials   []glbMaterial   `json:"materials,omitempty"`
+	Textures    []gltfTexture   `json:"textures,omitempty"`
+	Images      []glbImage      `json:"images,omitempty"`
+	Accessors   []glbAccessor   `json:"accessors"`
+	BufferViews []glbBufferView `json:"bufferViews"`
+	Buffers     []glbBuffer     `json:"buffers"`
+}
+
+type glbNode struct {
+	Name string `json:"name,omitempty"`
+	Mesh int    `json:"mesh"`
+}
+
+type glbMesh struct {
+	Name       string         `json:"name,omitempty"`
+	Primitives []glbPrimitive `json:"primitives"`
+}
+
+type glbPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    *int           `json:"indices,omitempty"`
+	Material   *int           `json:"material,omitempty"`
+	Mode       int            `json:"mode"`
+}
+
+type glbAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min,omitempty"`
+	Max           []float64 `json:"max,omitempty"`
+}
+
+type glbBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type glbBuffer struct {
+	ByteLength int `json:"byteLength"`
+}
+
+type glbMaterial struct {
+	Name                 string `json:"name,omitempty"`
+	PbrMetallicRoughness struct {
+		BaseColorFactor  [4]float64       `json:"baseColorFactor"`
+		BaseColorTexture *gltfTextureInfo `json:"baseColorTexture,omitempty"`
+		MetallicFactor   float64          `json:"metallicFactor"`
+		RoughnessFactor  float64          `json:"roughnessFactor"`
+	} `json:"pbrMetallicRoughness"`
+	AlphaMode string `json:"alphaMode,omitempty"`
+}
+
+type glbImage struct {
+	Name       string `json:"name,omitempty"`
+	MimeType   string `json:"mimeType"`
+	BufferView int    `json:"bufferView"`
+}
+
+// glbWriter builds the JSON document and binary chunk of one export.
+type glbWriter struct {
+	doc      glbDocument
+	bin      []byte
+	textures map[string]int
+}
+
+// exportGLB writes a mesh as a self-contained binary glTF: a single buffer
+// holds the geometry and any PNG or JPEG diffuse textures. glTF is measured
+// in metres, so positions are scaled from the mesh's unit. Groups with a
+// material become separate primitives; lines and points are kept as line
+// and point primitives.
+func exportGLB(m *Mesh, path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".gltf") {
+		return fmt.Errorf("glTF is only written as binary .glb")
+	}
+	if m.VertexCount() == 0 {
+		return fmt.Errorf("mesh has no vertices")
+	}
+	w := &glbWriter{textures: make(map[string]int)}
+	w.encode(m, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	doc, err := json.Marshal(w.doc)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, func(out io.Writer) error {
+		return writeGLB(out, doc, w.bin)
+	})
+}
+
+func (w *glbWriter) encode(m *Mesh, name string) {
+	w.doc.Asset.Version = "2.0"
+	w.doc.Asset.Generator = "Simple 3D Viewer"
+	w.doc.Scenes = []gltfScene{{Nodes: []int{0}}}
+	w.doc.Nodes = []glbNode{{Name: name, Mesh: 0}}
+
+	positions := m.Vertices
+	if f, ok := unitMetres[m.Units]; ok && f != 1 {
+		positions = make([]float32, len(m.Vertices))
+		for i, v := range m.Vertices {
+			positions[i] = v * float32(f)
+		}
+	}
+	attrs := map[string]int{"POSITION": w.floats(positions, "VEC3", true)}
+	if len(m.Normals) == len(m.Vertices) {
+		attrs["NORMAL"] = w.floats(m.Normals, "VEC3", false)
+	}
+	if len(m.UVs) == m.VertexCount()*2 {
+		attrs["TEXCOORD_0"] = w.floats(m.UVs, "VEC2", false)
+	}
+	if m.hasVertexColors() {
+		attrs["COLOR_0"] = w.floats(m.Colors, "VEC4", false)
+	}
+
+	materials := make([]int, len(m.Materials))
+	for i, mat := range m.Materials {
+		materials[i] = w.material(mat)
+	}
+	mesh := glbMesh{Name: name}
+	for _, run := range m.materialRuns() {
+		p := glbPrimitive{Attributes: attrs, Indices: ptrTo(w.indices(run.indices)), Mode: gltfTriangles}
+		if run.material >= 0 {
+			p.Material = ptrTo(materials[run.material])
+		}
+		mesh.Primitives = append(mesh.Primitives, p)
+	}
+	if len(m.Lines) >= 2 {
+		lines := m.Lines[:len(m.Lines)/2*2]
+		mesh.Primitives = append(mesh.Primitives, glbPrimitive{Attributes: attrs, Indices: ptrTo(w.indices(lines)), Mode: gltfLines})
+	}
+	switch {
+	case len(m.Points) > 0:
+		mesh.Primitives = append(mesh.Primitives, glbPrimitive{Attributes: attrs, Indices: ptrTo(w.indices(m.Points)), Mode: gltfPoints})
+	case len(mesh.Primitives) == 0:
+		// A point cloud draws every vertex, which needs no indices.
+		mesh.Primitives = append(mesh.Primitives, glbPrimitive{Attributes: attrs, Mode: gltfPoints})
+	}
+	w.doc.Meshes = []glbMesh{mesh}
+	w.doc.Buffers = []glbBuffer{{ByteLength: len(w.bin)}}
+}
+
+// materialRun is the triangles of a mesh that share a material.
+type materialRun struct {
+	material int
+	indices  []uint32
+}
+
+// materialRuns buckets triangles by the material of their group, in order of
+// first use. Without groups, a mesh's first material applies throughout, as
+// the OBJ exporter assumes.
+func (m *Mesh) materialRuns() []materialRun {
+	material := make([]int, m.TriangleCount())
+	for t := range material {
+		material[t] = -1
+	}
+	if len(m.Groups) == 0 && len(m.Materials) > 0 {
+		for t := range material {
+			material[t] = 0
+		}
+	}
+	for _, g := range m.Groups {
+		if g.Material < 0 || g.Material >= len(m.Materials) {
+			continue
+		}
+		for t := g.Start / 3; t < (g.Start+g.Count)/3 && t < len(material); t++ {
+			material[t] = g.Material
+		}
+	}
+
+	var runs []materialRun
+	run := make(map[int]int)
+	for t, mat := range material {
+		r, ok := run[mat]
+		if !ok {
+			r = len(runs)
+			run[mat] = r
+			runs = append(runs, materialRun{material: mat})
+		}
+		runs[r].indices = append(runs[r].indices, m.Indices[t*3:t*3+3]...)
+	}
+	return runs
+}
+
+// view appends data to the binary chunk, 4-byte aligned as accessors
+// require, and returns the new buffer view.
+func (w *glbWriter) view(data []byte, target int) int {
+	for len(w.bin)%4 != 0 {
+		w.bin = append(w.bin, 0)
+	}
+	w.doc.BufferViews = append(w.doc.BufferViews, glbBufferView{ByteOffset: len(w.bin), ByteLength: len(data), Target: target})
+	w.bin = append(w.bin, data...)
+	return len(w.doc.BufferViews) - 1
+}
+
+// floats stores a float vertex attribute and returns its accessor. glTF
+// requires the bounds of positions.
+func (w *glbWriter) floats(values []float32, typ string, bounds bool) int {
+	n := gltfComponents(typ)
+	data := make([]byte, 0, len(values)*4)
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+	}
+	acc := glbAccessor{
+		BufferView:    w.view(data, gltfArrayBuffer),
+		ComponentType: gltfFloat,
+		Count:         len(values) / n,
+		Type:          typ,
+	}
+	if bounds {
+		acc.Min, acc.Max = make([]float64, n), make([]float64, n)
+		for i, v := range values {
+			c := i % n
+			if i < n || float64(v) < acc.Min[c] {
+				acc.Min[c] = float64(v)
+			}
+			if i < n || float64(v) > acc.Max[c] {
+				acc.Max[c] = float64(v)
+			}
+		}
+	}
+	w.doc.Accessors = append(w.doc.Accessors, acc)
+	return len(w.doc.Accessors) - 1
+}
+
+func (w *glbWriter) indices(idx []uint32) int {
+	data := make([]byte, 0, len(idx)*4)
+	for _, i := range idx {
+		data = binary.LittleEndian.AppendUint32(data, i)
+	}
+	w.doc.Accessors = append(w.doc.Accessors, glbAccessor{
+		BufferView:    w.view(data, gltfElementArrayBuffer),
+		ComponentType: gltfUnsignedInt,
+		Count:         len(idx),
+		Type:          "SCALAR",
+	})
+	return len(w.doc.Accessors) - 1
+}
+
+// material converts a material to metallic-roughness. Materials from formats
+// without those factors come out as a fully rough dielectric, which is how
+// the viewer shows them.
+func (w *glbWriter) material(mat Material) int {
+	out := glbMaterial{Name: mat.Name}
+	pbr := &out.PbrMetallicRoughness
+	pbr.BaseColorFactor = [4]float64{float64(mat.DiffuseColor[0]), float64(mat.DiffuseColor[1]), float64(mat.DiffuseColor[2]), float64(mat.Opacity)}
+	pbr.MetallicFactor, pbr.RoughnessFactor = 0, 1
+	if mat.Metallic != nil {
+		pbr.MetallicFactor = float64(*mat.Metallic)
+	}
+	if mat.Roughness != nil {
+		pbr.RoughnessFactor = float64(*mat.Roughness)
+	}
+	if mat.Opacity < 1 {
+		out.AlphaMode = "BLEND"
+	}
+	if mat.DiffuseMap != "" {
+		if tex, ok := w.texture(mat.DiffuseMap); ok {
+			pbr.BaseColorTexture = &gltfTextureInfo{Index: tex}
+		}
+	}
+	w.doc.Materials = append(w.doc.Materials, out)
+	return len(w.doc.Materials) - 1
+}
+
+// texture embeds an image file once and returns its texture index. Images
+// glTF cannot hold, or that cannot be read, are left out with a warning so
+// the rest of the model is still written.
+func (w *glbWriter) texture(path string) (int, bool) {
+	if idx, ok := w.textures[path]; ok {
+		return idx, idx >= 0
+	}
+	w.textures[path] = -1
+	mime, ok := glbMimeTypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		appLog.warn("glb export: texture format not supported by glTF", "path", path)
+		return -1, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		appLog.warn("glb export: texture not embedded", "path", path, "error", err)
+		return -1, false
+	}
+	w.doc.Images = append(w.doc.Images, glbImage{
+		Name:       filepath.Base(path),
+		MimeType:   mime,
+		BufferView: w.view(data, 0),
+	})
+	w.doc.Textures = append(w.doc.Textures, gltfTexture{Source: ptrTo(len(w.doc.Images) - 1)})
+	idx := len(w.doc.Textures) - 1
+	w.textures[path] = idx
+	return idx, true
+}
+
+// writeGLB writes the 12-byte header and the JSON and BIN chunks, padding
+// them to 4 bytes with spaces and zeros as the container requires.
+func writeGLB(w io.Writer, jsonChunk, binChunk []byte) error {
+	for len(jsonChunk)%4 != 0 {
+		jsonChunk = append(jsonChunk, ' ')
+	}
+	for len(binChunk)%4 != 0 {
+		binChunk = append(binChunk, 0)
+	}
+	total := 12 + 8 + len(jsonChunk)
+	if len(binChunk) > 0 {
+		total += 8 + len(binChunk)
+	}
+
+	var buf []byte
+	buf = binary.LittleEndian.AppendUint32(buf, glbMagic)
+	buf = binary.LittleEndian.AppendUint32(buf, 2)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(total))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(jsonChunk)))
+	buf = binary.LittleEndian.AppendUint32(buf, glbChunkJSON)
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	if _, err := w.Write(jsonChunk); err != nil {
+		return err
+	}
+	if len(binChunk) == 0 {
+		return nil
+	}
+	buf = binary.LittleEndian.AppendUint32(buf[:0], uint32(len(binChunk)))
+	buf = binary.LittleEndian.AppendUint32(buf, glbChunkBIN)
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	_, err := w.Write(binChunk)
+	return err
+}
+
+func ptrTo(i int) *int {
+	return &i
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExportGLBRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\nnot really an image")
+	texPath := filepath.Join(dir, "red.png")
+	if err := os.WriteFile(texPath, png, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	metallic, roughness := float32(0.25), float32(0.5)
+	src := &Mesh{
+		Vertices: []float32{0, 0, 0, 1000, 0, 0, 1000, 1000, 0, 0, 1000, 0},
+		UVs:      []float32{0, 0, 1, 0, 1, 1, 0, 1},
+		Indices:  []uint32{0, 1, 2, 0, 2, 3},
+		Materials: []Material{
+			{Name: "red", DiffuseColor: [3]float32{1, 0, 0}, Opacity: 1, DiffuseMap: texPath, Metallic: &metallic, Roughness: &roughness},
+			{Name: "blue", DiffuseColor: [3]float32{0, 0, 1}, Opacity: 0.5},
+		},
+		Groups: []SubMesh{
+			{Name: "a", Start: 0, Count: 3, Material: 0},
+			{Name: "b", Start: 3, Count: 3, Material: 1},
+		},
+		Units: "mm",
+	}
+
+	path := filepath.Join(dir, "out.glb")
+	if err := exportGLB(src, path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := binary.LittleEndian.Uint32(data[8:]); int(got) != len(data) {
+		t.Errorf("header length = %d, file is %d bytes", got, len(data))
+	}
+	for off := 12; off+8 <= len(data); {
+		length := int(binary.LittleEndian.Uint32(data[off:]))
+		if off%4 != 0 || length%4 != 0 {
+			t.Errorf("chunk at %d has length %d, want both 4-byte aligned", off, length)
+		}
+		off += 8 + length
+	}
+
+	scene, err := parseGLTF(data, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prims := scene.Meshes[0].Primitives
+	if len(prims) != 2 {
+		t.Fatalf("got %d primitives, want 2", len(prims))
+	}
+	wantPos := []float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0}
+	for i, p := range prims {
+		if !slices.Equal(p.Mesh.Vertices, wantPos) {
+			t.Errorf("primitive %d positions = %v, want %v (metres)", i, p.Mesh.Vertices, wantPos)
+		}
+		if !slices.Equal(p.Mesh.UVs, src.UVs) {
+			t.Errorf("primitive %d UVs = %v, want %v", i, p.Mesh.UVs, src.UVs)
+		}
+		if p.Material != i {
+			t.Errorf("primitive %d material = %d, want %d", i, p.Material, i)
+		}
+	}
+	if got := prims[0].Mesh.Indices; !slices.Equal(got, []uint32{0, 1, 2}) {
+		t.Errorf("primitive 0 indices = %v", got)
+	}
+	if got := prims[1].Mesh.Indices; !slices.Equal(got, []uint32{0, 2, 3}) {
+		t.Errorf("primitive 1 indices = %v", got)
+	}
+
+	red, blue := scene.Materials[0], scene.Materials[1]
+	if red.Name != "red" || red.BaseColorFactor != [4]float64{1, 0, 0, 1} || red.MetallicFactor != 0.25 || red.RoughnessFactor != 0.5 {
+		t.Errorf("red material = %+v", red)
+	}
+	if blue.Name != "blue" || blue.BaseColorFactor != [4]float64{0, 0, 1, 0.5} || blue.AlphaMode != "BLEND" || blue.MetallicFactor != 0 || blue.RoughnessFactor != 1 {
+		t.Errorf("blue material = %+v", blue)
+	}
+	if red.BaseColorTexture < 0 || blue.BaseColorTexture != -1 {
+		t.Fatalf("base colour textures = %d, %d", red.BaseColorTexture, blue.BaseColorTexture)
+	}
+	img := scene.Images[scene.Textures[red.BaseColorTexture].Image]
+	if img.MimeType != "image/png" || !bytes.Equal(img.Data, png) {
+		t.Errorf("embedded image = %q %q, want the texture file's bytes", img.MimeType, img.Data)
+	}
+
+	flat := scene.flatten()
+	if len(flat.Groups) != 2 {
+		t.Fatalf("flattened groups = %+v, want 2", flat.Groups)
+	}
+	for i, g := range flat.Groups {
+		if g.Material != i || g.Count != 3 || flat.Materials[g.Material].Name != src.Materials[i].Name {
+			t.Errorf("group %d = %+v", i, g)
+		}
+	}
+}
@@ -0,0 +1,871 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	glbMagic     = 0x46546C67 // "glTF"
+	glbChunkJSON = 0x4E4F534A
+	glbChunkBIN  = 0x004E4942
+)
+
+// gltfDracoExtension marks primitives whose geometry is Draco-compressed.
+const gltfDracoExtension = "KHR_draco_mesh_compression"
+
+// gltfAppearanceExtensions may be required without stopping a load: they only
+// change how a model looks, and the core material or texture is used instead.
+// KHR_mesh_quantization is listed because accessors of every component type
+// are already decoded.
+var gltfAppearanceExtensions = map[string]bool{
+	"KHR_lights_punctual":   true,
+	"KHR_mesh_quantization": true,
+	"KHR_texture_basisu":    true,
+	"KHR_texture_transform": true,
+	"EXT_texture_avif":      true,
+	"EXT_texture_webp":      true,
+}
+
+// ErrUnsupportedExtension is returned for glTF files that require an
+// extension the loader cannot handle. The message names the extension.
+var ErrUnsupportedExtension = errors.New("unsupported glTF extension")
+
+// glTF component types.
+const (
+	gltfByte          = 5120
+	gltfUnsignedByte  = 5121
+	gltfShort         = 5122
+	gltfUnsignedShort = 5123
+	gltfUnsignedInt   = 5125
+	gltfFloat         = 5126
+)
+
+// glTF primitive modes.
+const (
+	gltfPoints        = 0
+	gltfLines         = 1
+	gltfTriangles     = 4
+	gltfTriangleStrip = 5
+	gltfTriangleFan   = 6
+)
+
+type gltfDocument struct {
+	Asset struct {
+		Version   string `json:"version"`
+		Generator string `json:"generator"`
+		Copyright string `json:"copyright"`
+	} `json:"asset"`
+	Scene       *int             `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+	Materials   []gltfMaterial   `json:"materials"`
+	Textures    []gltfTexture    `json:"textures"`
+	Images      []gltfImage      `json:"images"`
+	// ExtensionsRequired lists extensions without which the file cannot be
+	// read correctly.
+	ExtensionsRequired []string `json:"extensionsRequired"`
+	// Animations, skins and cameras are not decoded yet and are ignored.
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name        string    `json:"name"`
+	Children    []int     `json:"children"`
+	Mesh        *int      `json:"mesh"`
+	Matrix      []float64 `json:"matrix"`
+	Translation []float64 `json:"translation"`
+	Rotation    []float64 `json:"rotation"`
+	Scale       []float64 `json:"scale"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int             `json:"attributes"`
+	Indices    *int                       `json:"indices"`
+	Material   *int                       `json:"material"`
+	Mode       *int                       `json:"mode"`
+	Extensions map[string]json.RawMessage `json:"extensions"`
+}
+
+type gltfAccessor struct {
+	BufferView    *int        `json:"bufferView"`
+	ByteOffset    int         `json:"byteOffset"`
+	ComponentType int         `json:"componentType"`
+	Normalized    bool        `json:"normalized"`
+	Count         int         `json:"count"`
+	Type          string      `json:"type"`
+	Sparse        *gltfSparse `json:"sparse"`
+}
+
+type gltfSparse struct {
+	Count   int `json:"count"`
+	Indices struct {
+		BufferView    int `json:"bufferView"`
+		ByteOffset    int `json:"byteOffset"`
+		ComponentType int `json:"componentType"`
+	} `json:"indices"`
+	Values struct {
+		BufferView int `json:"bufferView"`
+		ByteOffset int `json:"byteOffset"`
+	} `json:"values"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	ByteStride int `json:"byteStride"`
+}
+
+type gltfBuffer struct {
+	URI        string `json:"uri"`
+	ByteLength int    `json:"byteLength"`
+}
+
+type gltfTextureInfo struct {
+	Index int `json:"index"`
+}
+
+type gltfMaterial struct {
+	Name                 string `json:"name"`
+	PbrMetallicRoughness *struct {
+		BaseColorFactor          []float64        `json:"baseColorFactor"`
+		BaseColorTexture         *gltfTextureInfo `json:"baseColorTexture"`
+		MetallicFactor           *float64         `json:"metallicFactor"`
+		RoughnessFactor          *float64         `json:"roughnessFactor"`
+		MetallicRoughnessTexture *gltfTextureInfo `json:"metallicRoughnessTexture"`
+	} `json:"pbrMetallicRoughness"`
+	NormalTexture    *gltfTextureInfo `json:"normalTexture"`
+	OcclusionTexture *gltfTextureInfo `json:"occlusionTexture"`
+	EmissiveTexture  *gltfTextureInfo `json:"emissiveTexture"`
+	EmissiveFactor   []float64        `json:"emissiveFactor"`
+	AlphaMode        string           `json:"alphaMode"`
+	DoubleSided      bool             `json:"doubleSided"`
+}
+
+type gltfTexture struct {
+	Source *int `json:"source"`
+}
+
+type gltfImage struct {
+	Name       string `json:"name"`
+	URI        string `json:"uri"`
+	MimeType   string `json:"mimeType"`
+	BufferView *int   `json:"bufferView"`
+}
+
+// gltfReader resolves buffers and accessors of one document.
+type gltfReader struct {
+	doc     gltfDocument
+	dir     string
+	buffers [][]byte
+}
+
+// LoadGLTF parses a .gltf (with external or embedded resources) or .glb file.
+func (a *App) LoadGLTF(path string) (*Scene, error) {
+	start := time.Now()
+	ctx, hash := withContentHash(context.Background(), path)
+	scene, err := loadGLTF(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	scene.Meta = describeFile(scene.Meta, path, formatForExtension(".gltf").name, time.Since(start))
+	scene.Meta.Hash = hash.sum()
+	abs := a.addRecentFile(path, scene.Meta.Hash)
+	a.stopWatchingOthers(abs)
+	a.warnIfHeavy(abs, scene.triangleCount())
+	return a.meshes.addScene(scene), nil
+}
+
+func loadGLTF(ctx context.Context, path string, progress progressFunc) (*Scene, error) {
+	data, err := readFileWithProgress(ctx, path, progress)
+	if err != nil {
+		return nil, fmt.Errorf("gltf: %w", err)
+	}
+	scene, err := parseGLTF(data, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("gltf: %w", err)
+	}
+	return scene, nil
+}
+
+// parseGLTF decodes glTF JSON or a GLB container. dir resolves external URIs.
+func parseGLTF(data []byte, dir string) (*Scene, error) {
+	jsonChunk, binChunk := data, []byte(nil)
+	if len(data) >= 4 && binary.LittleEndian.Uint32(data) == glbMagic {
+		var err error
+		if jsonChunk, binChunk, err = splitGLB(data); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &gltfReader{dir: dir}
+	if err := json.Unmarshal(jsonChunk, &r.doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	// Required extensions that change how geometry is stored are fatal. Files
+	// that merely use Draco also carry uncompressed fallback accessors.
+	for _, name := range r.doc.ExtensionsRequired {
+		if !gltfAppearanceExtensions[name] && !strings.HasPrefix(name, "KHR_materials_") {
+			return nil, unsupportedExtension(name)
+		}
+	}
+	if err := r.loadBuffers(binChunk); err != nil {
+		return nil, err
+	}
+	scene, err := r.scene()
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &ModelMeta{}
+	for _, c := range [][2]string{
+		{"version", r.doc.Asset.Version},
+		{"generator", r.doc.Asset.Generator},
+		{"copyright", r.doc.Asset.Copyright},
+	} {
+		if c[1] != "" {
+			meta.addComment(c[0] + ": " + c[1])
+		}
+	}
+	scene.Meta = meta
+	return scene, nil
+}
+
+// splitGLB returns the JSON and optional BIN chunk of a GLB container.
+func splitGLB(data []byte) (jsonChunk, binChunk []byte, err error) {
+	if len(data) < 20 {
+		return nil, nil, fmt.Errorf("glb: truncated header")
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != 2 {
+		return nil, nil, fmt.Errorf("glb: unsupported container version %d", v)
+	}
+	if n := binary.LittleEndian.Uint32(data[8:]); int(n) < len(data) {
+		data = data[:n]
+	}
+
+	for off := 12; off+8 <= len(data); {
+		length := int(binary.LittleEndian.Uint32(data[off:]))
+		kind := binary.LittleEndian.Uint32(data[off+4:])
+		start := off + 8
+		if length < 0 || start+length > len(data) {
+			return nil, nil, fmt.Errorf("glb: chunk at offset %d exceeds file length", off)
+		}
+		switch kind {
+		case glbChunkJSON:
+			if jsonChunk == nil {
+				jsonChunk = data[start : start+length]
+			}
+		case glbChunkBIN:
+			if binChunk == nil {
+				binChunk = data[start : start+length]
+			}
+		}
+		off = start + length
+	}
+	if jsonChunk == nil {
+		return nil, nil, fmt.Errorf("glb: missing JSON chunk")
+	}
+	return jsonChunk, binChunk, nil
+}
+
+func (r *gltfReader) loadBuffers(binChunk []byte) error {
+	r.buffers = make([][]byte, len(r.doc.Buffers))
+	for i, b := range r.doc.Buffers {
+		var data []byte
+		var err error
+		switch {
+		case b.URI == "":
+			if i != 0 || binChunk == nil {
+				return fmt.Errorf("buffer %d has no uri and no GLB binary chunk", i)
+			}
+			data = binChunk
+		default:
+			data, _, err = r.readURI(b.URI)
+			if err != nil {
+				return fmt.Errorf("buffer %d: %w", i, err)
+			}
+		}
+		if len(data) < b.ByteLength {
+			return fmt.Errorf("buffer %d: has %d bytes, expected %d", i, len(data), b.ByteLength)
+		}
+		r.buffers[i] = data
+	}
+	return nil
+}
+
+// readURI returns the bytes behind a data: URI, or reads an external file
+// relative to the document. The resolved path is returned for external files.
+func (r *gltfReader) readURI(uri string) ([]byte, string, error) {
+	if strings.HasPrefix(uri, "data:") {
+		comma := strings.IndexByte(uri, ',')
+		if comma < 0 || !strings.HasSuffix(uri[:comma], ";base64") {
+			return nil, "", fmt.Errorf("unsupported data URI encoding")
+		}
+		data, err := base64.StdEncoding.DecodeString(uri[comma+1:])
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid base64 data URI: %w", err)
+		}
+		return data, "", nil
+	}
+
+	ref, err := url.PathUnescape(uri)
+	if err != nil {
+		ref = uri
+	}
+	path := resolveAssetPath(r.dir, ref)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("%q not found", path)
+		}
+		return nil, "", err
+	}
+	return data, path, nil
+}
+
+func (r *gltfReader) scene() (*Scene, error) {
+	s := &Scene{
+		Nodes:     make([]SceneNode, len(r.doc.Nodes)),
+		Meshes:    make([]SceneMesh, len(r.doc.Meshes)),
+		Materials: make([]PBRMaterial, len(r.doc.Materials)),
+		Textures:  make([]SceneTexture, len(r.doc.Textures)),
+		Images:    make([]SceneImage, len(r.doc.Images)),
+	}
+
+	for i, n := range r.doc.Nodes {
+		node, err := r.node(n)
+		if err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		s.Nodes[i] = node
+	}
+	for i, m := range r.doc.Meshes {
+		mesh, err := r.mesh(m)
+		if err != nil {
+			return nil, fmt.Errorf("mesh %d: %w", i, err)
+		}
+		s.Meshes[i] = mesh
+	}
+	for i, m := range r.doc.Materials {
+		s.Materials[i] = r.material(m)
+	}
+	for i, t := range r.doc.Textures {
+		s.Textures[i] = SceneTexture{Image: optIndex(t.Source)}
+	}
+	for i, img := range r.doc.Images {
+		image, err := r.image(img)
+		if err != nil {
+			return nil, fmt.Errorf("image %d: %w", i, err)
+		}
+		s.Images[i] = image
+	}
+
+	roots, err := r.roots()
+	if err != nil {
+		return nil, err
+	}
+	s.Roots = roots
+	for _, n := range s.Roots {
+		if n < 0 || n >= len(s.Nodes) {
+			return nil, fmt.Errorf("scene root %d out of range", n)
+		}
+	}
+	return s, nil
+}
+
+func (r *gltfReader) node(n gltfNode) (SceneNode, error) {
+	node := SceneNode{
+		Name:     n.Name,
+		Children: n.Children,
+		Mesh:     optIndex(n.Mesh),
+		Rotation: [4]float64{0, 0, 0, 1},
+		Scale:    [3]float64{1, 1, 1},
+	}
+	for _, c := range n.Children {
+		if c < 0 || c >= len(r.doc.Nodes) {
+			return node, fmt.Errorf("child %d out of range", c)
+		}
+	}
+	if n.Mesh != nil && (*n.Mesh < 0 || *n.Mesh >= len(r.doc.Meshes)) {
+		return node, fmt.Errorf("mesh %d out of range", *n.Mesh)
+	}
+	if len(n.Matrix) == 16 {
+		var m [16]float64
+		copy(m[:], n.Matrix)
+		node.Matrix = &m
+	}
+	copy(node.Translation[:], n.Translation)
+	copy(node.Rotation[:], n.Rotation)
+	copy(node.Scale[:], n.Scale)
+	return node, nil
+}
+
+// roots returns the nodes of the default scene, or every parentless node
+// when the document declares no scenes.
+func (r *gltfReader) roots() ([]int, error) {
+	if r.doc.Scene != nil && (*r.doc.Scene < 0 || *r.doc.Scene >= len(r.doc.Scenes)) {
+		return nil, fmt.Errorf("scene %d out of range", *r.doc.Scene)
+	}
+	if len(r.doc.Scenes) > 0 {
+		idx := 0
+		if r.doc.Scene != nil {
+			idx = *r.doc.Scene
+		}
+		return r.doc.Scenes[idx].Nodes, nil
+	}
+
+	isChild := make([]bool, len(r.doc.Nodes))
+	for _, n := range r.doc.Nodes {
+		for _, c := range n.Children {
+			isChild[c] = true
+		}
+	}
+	var roots []int
+	for i, child := range isChild {
+		if !child {
+			roots = append(roots, i)
+		}
+	}
+	return roots, nil
+}
+
+func (r *gltfReader) mesh(m gltfMesh) (SceneMesh, error) {
+	out := SceneMesh{Name: m.Name, Primitives: make([]Primitive, 0, len(m.Primitives))}
+	for i, p := range m.Primitives {
+		prim, err := r.primitive(p)
+		if err != nil {
+			return out, fmt.Errorf("primitive %d: %w", i, err)
+		}
+		out.Primitives = append(out.Primitives, prim)
+	}
+	return out, nil
+}
+
+func (r *gltfReader) primitive(p gltfPrimitive) (Primitive, error) {
+	// glTF coordinates are metres by definition.
+	prim := Primitive{Mesh: &Mesh{Units: "m"}, Material: optIndex(p.Material), Mode: gltfTriangles}
+	if p.Mode != nil {
+		prim.Mode = *p.Mode
+	}
+
+	pos, ok := p.Attributes["POSITION"]
+	if !ok {
+		return prim, fmt.Errorf("missing POSITION attribute")
+	}
+	if _, ok := p.Extensions[gltfDracoExtension]; ok && !r.hasData(pos) {
+		return prim, unsupportedExtension(gltfDracoExtension)
+	}
+	var err error
+	if prim.Mesh.Vertices, err = r.floats(pos, "VEC3"); err != nil {
+		return prim, fmt.Errorf("POSITION: %w", err)
+	}
+	if idx, ok := p.Attributes["NORMAL"]; ok {
+		if prim.Mesh.Normals, err = r.floats(idx, "VEC3"); err != nil {
+			return prim, fmt.Errorf("NORMAL: %w", err)
+		}
+		if len(prim.Mesh.Normals) != len(prim.Mesh.Vertices) {
+			return prim, fmt.Errorf("NORMAL: %d values for %d positions", len(prim.Mesh.Normals)/3, len(prim.Mesh.Vertices)/3)
+		}
+	}
+	if idx, ok := p.Attributes["TEXCOORD_0"]; ok {
+		if prim.Mesh.UVs, err = r.floats(idx, "VEC2"); err != nil {
+			return prim, fmt.Errorf("TEXCOORD_0: %w", err)
+		}
+		if len(prim.Mesh.UVs)/2 != len(prim.Mesh.Vertices)/3 {
+			return prim, fmt.Errorf("TEXCOORD_0: %d values for %d positions", len(prim.Mesh.UVs)/2, len(prim.Mesh.Vertices)/3)
+		}
+	}
+
+	count := uint32(len(prim.Mesh.Vertices) / 3)
+	if p.Indices != nil {
+		if prim.Mesh.Indices, err = r.indices(*p.Indices); err != nil {
+			return prim, fmt.Errorf("indices: %w", err)
+		}
+		for _, i := range prim.Mesh.Indices {
+			if i >= count {
+				return prim, fmt.Errorf("index %d out of range (%d vertices)", i, count)
+			}
+		}
+	} else {
+		prim.Mesh.Indices = make([]uint32, count)
+		for i := range prim.Mesh.Indices {
+			prim.Mesh.Indices[i] = uint32(i)
+		}
+	}
+
+	switch prim.Mode {
+	case gltfTriangleStrip:
+		prim.Mesh.Indices = stripToTriangles(prim.Mesh.Indices)
+		prim.Mode = gltfTriangles
+	case gltfTriangleFan:
+		prim.Mesh.Indices = fanToTriangles(prim.Mesh.Indices)
+		prim.Mode = gltfTriangles
+	}
+	return prim, nil
+}
+
+func stripToTriangles(strip []uint32) []uint32 {
+	var out []uint32
+	for i := 0; i+2 < len(strip); i++ {
+		if i%2 == 0 {
+			out = append(out, strip[i], strip[i+1], strip[i+2])
+		} else {
+			out = append(out, strip[i+1], strip[i], strip[i+2])
+		}
+	}
+	return out
+}
+
+func fanToTriangles(fan []uint32) []uint32 {
+	var out []uint32
+	for i := 1; i+1 < len(fan); i++ {
+		out = append(out, fan[0], fan[i], fan[i+1])
+	}
+	return out
+}
+
+// hasData reports whether accessor idx has a buffer view or sparse values to
+// read, rather than being filled in by an extension.
+func (r *gltfReader) hasData(idx int) bool {
+	if idx < 0 || idx >= len(r.doc.Accessors) {
+		return false
+	}
+	acc := r.doc.Accessors[idx]
+	return acc.BufferView != nil || acc.Sparse != nil
+}
+
+// unsupportedExtension wraps ErrUnsupportedExtension with the extension name.
+// There is no pure-Go Draco decoder to bundle, so Draco gets its own hint.
+func unsupportedExtension(name string) error {
+	if name == gltfDracoExtension {
+		return fmt.Errorf("%w: %s (Draco-compressed meshes cannot be decoded; re-export without compression)", ErrUnsupportedExtension, name)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedExtension, name)
+}
+
+func (r *gltfReader) material(m gltfMaterial) PBRMaterial {
+	mat := PBRMaterial{
+		Name:                     m.Name,
+		BaseColorFactor:          [4]float64{1, 1, 1, 1},
+		MetallicFactor:           1,
+		RoughnessFactor:          1,
+		BaseColorTexture:         -1,
+		MetallicRoughnessTexture: -1,
+		NormalTexture:            textureIndex(m.NormalTexture),
+		OcclusionTexture:         textureIndex(m.OcclusionTexture),
+		EmissiveTexture:          textureIndex(m.EmissiveTexture),
+		AlphaMode:                m.AlphaMode,
+		DoubleSided:              m.DoubleSided,
+	}
+	if mat.AlphaMode == "" {
+		mat.AlphaMode = "OPAQUE"
+	}
+	copy(mat.EmissiveFactor[:], m.EmissiveFactor)
+
+	if pbr := m.PbrMetallicRoughness; pbr != nil {
+		copy(mat.BaseColorFactor[:], pbr.BaseColorFactor)
+		if pbr.MetallicFactor != nil {
+			mat.MetallicFactor = *pbr.MetallicFactor
+		}
+		if pbr.RoughnessFactor != nil {
+			mat.RoughnessFactor = *pbr.RoughnessFactor
+		}
+		mat.BaseColorTexture = textureIndex(pbr.BaseColorTexture)
+		mat.MetallicRoughnessTexture = textureIndex(pbr.MetallicRoughnessTexture)
+	}
+	return mat
+}
+
+func (r *gltfReader) image(img gltfImage) (SceneImage, error) {
+	out := SceneImage{Name: img.Name, MimeType: img.MimeType}
+	switch {
+	case img.BufferView != nil:
+		data, _, err := r.view(*img.BufferView)
+		if err != nil {
+			return out, err
+		}
+		out.Data = bytes.Clone(data)
+	case strings.HasPrefix(img.URI, "data:"):
+		data, _, err := r.readURI(img.URI)
+		if err != nil {
+			return out, err
+		}
+		out.Data = data
+		if out.MimeType == "" {
+			out.MimeType = strings.TrimSuffix(strings.TrimPrefix(img.URI[:strings.IndexByte(img.URI, ',')], "data:"), ";base64")
+		}
+	case img.URI != "":
+		ref, err := url.PathUnescape(img.URI)
+		if err != nil {
+			ref = img.URI
+		}
+		out.Path = resolveAssetPath(r.dir, ref)
+		if _, err := os.Stat(out.Path); err != nil {
+			return out, fmt.Errorf("%q not found", out.Path)
+		}
+	}
+	return out, nil
+}
+
+// view returns the bytes of a buffer view together with its stride.
+func (r *gltfReader) view(idx int) ([]byte, int, error) {
+	if idx < 0 || idx >= len(r.doc.BufferViews) {
+		return nil, 0, fmt.Errorf("bufferView %d out of range", idx)
+	}
+	bv := r.doc.BufferViews[idx]
+	if bv.Buffer < 0 || bv.Buffer >= len(r.buffers) {
+		return nil, 0, fmt.Errorf("bufferView %d: buffer %d out of range", idx, bv.Buffer)
+	}
+	buf := r.buffers[bv.Buffer]
+	if bv.ByteOffset < 0 || bv.ByteLength < 0 || bv.ByteStride < 0 || bv.ByteOffset+bv.ByteLength > len(buf) {
+		return nil, 0, fmt.Errorf("bufferView %d exceeds buffer %d", idx, bv.Buffer)
+	}
+	return buf[bv.ByteOffset : bv.ByteOffset+bv.ByteLength], bv.ByteStride, nil
+}
+
+// floats decodes an accessor of the expected type into float32 values,
+// applying normalisation and sparse substitution.
+func (r *gltfReader) floats(idx int, wantType string) ([]float32, error) {
+	if idx < 0 || idx >= len(r.doc.Accessors) {
+		return nil, fmt.Errorf("accessor %d out of range", idx)
+	}
+	acc := r.doc.Accessors[idx]
+	if acc.Type != wantType {
+		return nil, fmt.Errorf("accessor %d: type %s, expected %s", idx, acc.Type, wantType)
+	}
+	comps := gltfComponents(acc.Type)
+	size := gltfComponentSize(acc.ComponentType)
+	if size == 0 {
+		return nil, fmt.Errorf("accessor %d: unknown component type %d", idx, acc.ComponentType)
+	}
+	if acc.Count < 0 || acc.ByteOffset < 0 {
+		return nil, fmt.Errorf("accessor %d: negative count or offset", idx)
+	}
+
+	// The data is checked against its buffer view before anything is
+	// allocated, so a corrupt count cannot claim gigabytes.
+	var data []byte
+	stride := comps * size
+	if acc.BufferView != nil {
+		var err error
+		var viewStride int
+		if data, viewStride, err = r.view(*acc.BufferView); err != nil {
+			return nil, err
+		}
+		if viewStride != 0 {
+			stride = viewStride
+		}
+		if !accessorFits(len(data), acc.ByteOffset, acc.Count, stride, comps*size) {
+			return nil, fmt.Errorf("accessor %d exceeds its buffer view", idx)
+		}
+	}
+
+	var indices []uint32
+	var values []byte
+	sp := acc.Sparse
+	if sp != nil && sp.Count > 0 {
+		if sp.Count > acc.Count || sp.Values.ByteOffset < 0 {
+			return nil, fmt.Errorf("accessor %d: invalid sparse count or offset", idx)
+		}
+		var err error
+		indices, err = r.rawIndices(sp.Indices.BufferView, sp.Indices.ByteOffset, sp.Indices.ComponentType, sp.Count)
+		if err != nil {
+			return nil, fmt.Errorf("accessor %d sparse indices: %w", idx, err)
+		}
+		if values, _, err = r.view(sp.Values.BufferView); err != nil {
+			return nil, fmt.Errorf("accessor %d sparse values: %w", idx, err)
+		}
+		if !accessorFits(len(values), sp.Values.ByteOffset, sp.Count, comps*size, comps*size) {
+			return nil, fmt.Errorf("accessor %d sparse values exceed buffer view", idx)
+		}
+	}
+
+	out := make([]float32, acc.Count*comps)
+	if data != nil {
+		for i := 0; i < acc.Count; i++ {
+			base := acc.ByteOffset + i*stride
+			for c := 0; c < comps; c++ {
+				out[i*comps+c] = gltfReadComponent(data[base+c*size:], acc.ComponentType, acc.Normalized)
+			}
+		}
+	}
+
+	if values != nil {
+		for i, target := range indices {
+			if int(target) >= acc.Count {
+				return nil, fmt.Errorf("accessor %d sparse index %d out of range", idx, target)
+			}
+			for c := 0; c < comps; c++ {
+				off := sp.Values.ByteOffset + (i*comps+c)*size
+				out[int(target)*comps+c] = gltfReadComponent(values[off:], acc.ComponentType, acc.Normalized)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (r *gltfReader) indices(idx int) ([]uint32, error) {
+	if idx < 0 || idx >= len(r.doc.Accessors) {
+		return nil, fmt.Errorf("accessor %d out of range", idx)
+	}
+	acc := r.doc.Accessors[idx]
+	if acc.Type != "SCALAR" {
+		return nil, fmt.Errorf("accessor %d: type %s, expected SCALAR", idx, acc.Type)
+	}
+	if acc.Count < 0 {
+		return nil, fmt.Errorf("accessor %d: negative count", idx)
+	}
+	if acc.BufferView == nil {
+		return make([]uint32, acc.Count), nil
+	}
+	return r.rawIndices(*acc.BufferView, acc.ByteOffset, acc.ComponentType, acc.Count)
+}
+
+func (r *gltfReader) rawIndices(view, offset, componentType, count int) ([]uint32, error) {
+	data, stride, err := r.view(view)
+	if err != nil {
+		return nil, err
+	}
+	size := gltfComponentSize(componentType)
+	if componentType != gltfUnsignedByte && componentType != gltfUnsignedShort && componentType != gltfUnsignedInt {
+		return nil, fmt.Errorf("invalid index component type %d", componentType)
+	}
+	if stride == 0 {
+		stride = size
+	}
+	if !accessorFits(len(data), offset, count, stride, size) {
+		return nil, fmt.Errorf("indices exceed buffer view")
+	}
+
+	out := make([]uint32, count)
+	for i := range out {
+		b := data[offset+i*stride:]
+		switch componentType {
+		case gltfUnsignedByte:
+			out[i] = uint32(b[0])
+		case gltfUnsignedShort:
+			out[i] = uint32(binary.LittleEndian.Uint16(b))
+		default:
+			out[i] = binary.LittleEndian.Uint32(b)
+		}
+	}
+	return out, nil
+}
+
+// accessorFits reports whether count elements of elem bytes, stride bytes
+// apart from offset, lie within n bytes. It is written to avoid overflow, as
+// all the figures come from the file.
+func accessorFits(n, offset, count, stride, elem int) bool {
+	if offset < 0 || count < 0 || stride <= 0 || elem <= 0 {
+		return false
+	}
+	if count == 0 {
+		return offset <= n
+	}
+	avail := n - offset - elem
+	return avail >= 0 && count-1 <= avail/stride
+}
+
+func gltfComponents(t string) int {
+	switch t {
+	case "SCALAR":
+		return 1
+	case "VEC2":
+		return 2
+	case "VEC3":
+		return 3
+	case "VEC4", "MAT2":
+		return 4
+	case "MAT3":
+		return 9
+	case "MAT4":
+		return 16
+	}
+	return 0
+}
+
+func gltfComponentSize(componentType int) int {
+	switch componentType {
+	case gltfByte, gltfUnsignedByte:
+		return 1
+	case gltfShort, gltfUnsignedShort:
+		return 2
+	case gltfUnsignedInt, gltfFloat:
+		return 4
+	}
+	return 0
+}
+
+// gltfReadComponent decodes one little-endian component, mapping normalised
+// integers to [0,1] or [-1,1] as the spec requires.
+func gltfReadComponent(b []byte, componentType int, normalized bool) float32 {
+	switch componentType {
+	case gltfFloat:
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case gltfByte:
+		v := float32(int8(b[0]))
+		if normalized {
+			return max(v/127, -1)
+		}
+		return v
+	case gltfUnsignedByte:
+		v := float32(b[0])
+		if normalized {
+			return v / 255
+		}
+		return v
+	case gltfShort:
+		v := float32(int16(binary.LittleEndian.Uint16(b)))
+		if normalized {
+			return max(v/32767, -1)
+		}
+		return v
+	case gltfUnsignedShort:
+		v := float32(binary.LittleEndian.Uint16(b))
+		if normalized {
+			return v / 65535
+		}
+		return v
+	case gltfUnsignedInt:
+		return float32(binary.LittleEndian.Uint32(b))
+	}
+	return 0
+}
+
+func textureIndex(t *gltfTextureInfo) int {
+	if t == nil {
+		return -1
+	}
+	return t.Index
+}
+
+func optIndex(i *int) int {
+	if i == nil {
+		return -1
+	}
+	return *i
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// triangleGLTF returns a one-triangle document whose buffer holds three
+// positions followed by one normal. normal is the accessor JSON used for
+// NORMAL, or "" for none.
+func triangleGLTF(position, normal string) []byte {
+	buf := make([]byte, 48)
+	binary.LittleEndian.PutUint32(buf[12:], math.Float32bits(1))
+	binary.LittleEndian.PutUint32(buf[28:], math.Float32bits(1))
+	uri := "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(buf)
+	attrs := `"POSITION": 0`
+	accessors := position
+	if normal != "" {
+		attrs += `, "NORMAL": 1`
+		accessors += ", " + normal
+	}
+	return []byte(fmt.Sprintf(`{
+		"asset": {"version": "2.0"},
+		"scenes": [{"nodes": [0]}],
+		"nodes": [{"mesh": 0}],
+		"meshes": [{"primitives": [{"attributes": {%s}}]}],
+		"accessors": [%s],
+		"bufferViews": [{"buffer": 0, "byteLength": 48}],
+		"buffers": [{"uri": %q, "byteLength": 48}]
+	}`, attrs, accessors, uri))
+}
+
+func TestParseGLTFRejectsMalformedAccessors(t *testing.T) {
+	const position = `{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}`
+	if _, err := parseGLTF(triangleGLTF(position, ""), ""); err != nil {
+		t.Fatalf("valid document: %v", err)
+	}
+
+	tests := []struct {
+		name, position, normal, want string
+	}{
+		{"short normals", position, `{"bufferView": 0, "byteOffset": 36, "componentType": 5126, "count": 1, "type": "VEC3"}`, "NORMAL: 1 values for 3 positions"},
+		{"negative count", `{"bufferView": 0, "componentType": 5126, "count": -1, "type": "VEC3"}`, "", "negative count"},
+		{"negative offset", `{"bufferView": 0, "byteOffset": -4, "componentType": 5126, "count": 3, "type": "VEC3"}`, "", "negative count or offset"},
+		{"huge count", `{"bufferView": 0, "componentType": 5126, "count": 4611686018427387903, "type": "VEC3"}`, "", "exceeds its buffer view"},
+		{"sparse beyond count", `{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3",
+			"sparse": {"count": 4, "indices": {"bufferView": 0, "componentType": 5125}, "values": {"bufferView": 0}}}`, "", "invalid sparse count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGLTF(triangleGLTF(tt.position, tt.normal), "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGLTFRequiredExtensions(t *testing.T) {
+	const position = `{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}`
+	tests := []struct {
+		name  string
+		fatal bool
+	}{
+		{"KHR_materials_unlit", false},
+		{"KHR_materials_clearcoat", false},
+		{"KHR_texture_transform", false},
+		{"KHR_mesh_quantization", false},
+		{gltfDracoExtension, true},
+		{"EXT_meshopt_compression", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := strings.Replace(string(triangleGLTF(position, "")), `"asset"`,
+				fmt.Sprintf(`"extensionsUsed": [%[1]q], "extensionsRequired": [%[1]q], "asset"`, tt.name), 1)
+			_, err := parseGLTF([]byte(doc), "")
+			if got := errors.Is(err, ErrUnsupportedExtension); got != tt.fatal {
+				t.Errorf("error = %v, want unsupported extension %v", err, tt.fatal)
+			}
+			if !tt.fatal && err != nil {
+				t.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseGLTFRejectsBadSceneAndMeshIndices(t *testing.T) {
+	const position = `{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}`
+	tests := []struct {
+		name, old, new, want string
+	}{
+		{"negative scene", `"asset"`, `"scene": -1, "asset"`, "scene -1 out of range"},
+		{"scene past the end", `"asset"`, `"scene": 1, "asset"`, "scene 1 out of range"},
+		{"negative mesh", `{"mesh": 0}`, `{"mesh": -1}`, "node 0: mesh -1 out of range"},
+		{"mesh past the end", `{"mesh": 0}`, `{"mesh": 1}`, "node 0: mesh 1 out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := strings.Replace(string(triangleGLTF(position, "")), tt.old, tt.new, 1)
+			_, err := parseGLTF([]byte(doc), "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gzipMagic starts every gzip stream.
+const gzipMagic = "\x1f\x8b"
+
+// ErrCorruptGzip is returned when a compressed model cannot be inflated, as
+// opposed to inflating fine but failing to parse.
+var ErrCorruptGzip = errors.New("corrupt gzip stream")
+
+// modelExtension returns the lower-cased extension of path that identifies
+// its format, looking through a trailing .gz, so "model.obj.gz" gives ".obj".
+func modelExtension(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+	return ext
+}
+
+// gzipped reports whether f starts with the gzip magic. The suffix is not
+// trusted, so a misnamed plain file still loads.
+func gzipped(f io.ReaderAt) bool {
+	var magic [2]byte
+	_, err := f.ReadAt(magic[:], 0)
+	return err == nil && string(magic[:]) == gzipMagic
+}
+
+// isGzipFile reports whether the file at path is gzip-compressed.
+func isGzipFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return gzipped(f)
+}
+
+func corruptGzip(err error) error {
+	return fmt.Errorf("%w: %v", ErrCorruptGzip, err)
+}
+
+// verifyGzip inflates the whole of path and returns an ErrCorruptGzip error
+// if the stream is damaged. Loaders only see a read error, which they may
+// report as a parse failure, so this tells the two apart after the fact.
+func verifyGzip(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return corruptGzip(err)
+	}
+	if _, err := io.Copy(io.Discard, zr); err != nil {
+		return corruptGzip(err)
+	}
+	return nil
+}
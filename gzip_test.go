@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestLoadGzippedModels(t *testing.T) {
+	obj := []byte("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n")
+	packed := gzipBytes(t, obj)
+	tests := []struct {
+		name, file string
+		data       []byte
+		format     string
+		corrupt    bool
+		err        string
+	}{
+		{name: "obj", file: "part.obj.gz", data: packed, format: "(gzip)"},
+		{name: "binary stl", file: "part.stl.gz", data: gzipBytes(t, binarySTL("cad", -1, quadTriangles)), format: "(gzip)"},
+		{name: "misnamed plain file", file: "plain.obj.gz", data: obj},
+		{name: "truncated stream", file: "cut.obj.gz", data: packed[:len(packed)-6], corrupt: true},
+		{name: "bad model inside a good stream", file: "bad.off.gz", data: gzipBytes(t, []byte("OFF\n3 1 0\n0 0 0\n")), err: "vertex 1 of 3"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			m, err := loadModel(context.Background(), path, nil)
+			switch {
+			case tt.corrupt:
+				if !errors.Is(err, ErrCorruptGzip) {
+					t.Errorf("error = %v, want ErrCorruptGzip", err)
+				}
+			case tt.err != "":
+				if err == nil || errors.Is(err, ErrCorruptGzip) || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want a parse error containing %q", err, tt.err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case m.TriangleCount() == 0 || !strings.HasSuffix(m.Meta.Format, tt.format):
+				t.Errorf("%d triangles, format %q; want %q", m.TriangleCount(), m.Meta.Format, tt.format)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"time"
+)
+
+// contentHashKey carries a *contentHash in a load's context.
+type contentHashKey struct{}
+
+// contentHash digests a model file as its loader reads it, so identifying
+// the content costs no extra pass over the file in the common case.
+type contentHash struct {
+	path     string
+	h        hash.Hash
+	n        int64
+	attached bool
+}
+
+// withContentHash returns a context under which the first stream opened on
+// path is hashed.
+func withContentHash(ctx context.Context, path string) (context.Context, *contentHash) {
+	c := &contentHash{path: path, h: sha256.New()}
+	return context.WithValue(ctx, contentHashKey{}, c), c
+}
+
+// contentHashFor claims ctx's hash for a stream over path, or returns nil if
+// there is none, it is for another file, or another stream already has it.
+func contentHashFor(ctx context.Context, path string) *contentHash {
+	c, ok := ctx.Value(contentHashKey{}).(*contentHash)
+	if !ok || c.attached || c.path != path {
+		return nil
+	}
+	c.attached = true
+	return c
+}
+
+func (c *contentHash) write(b []byte) {
+	c.h.Write(b)
+	c.n += int64(len(b))
+}
+
+// sum returns the hex SHA-256 of the file. Loaders that seek, or stop before
+// the end, leave the streamed digest incomplete; the file is then hashed
+// separately. It returns "" if the file cannot be read.
+func (c *contentHash) sum() string {
+	if info, err := os.Stat(c.path); err == nil && c.attached && c.n == info.Size() {
+		return hex.EncodeToString(c.h.Sum(nil))
+	}
+	sum, err := hashFile(c.path)
+	if err != nil {
+		appLog.warn("hashing model failed", "path", c.path, "error", err)
+		return ""
+	}
+	return sum
+}
+
+// hashFile returns the hex SHA-256 of a file's bytes.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetModelHash returns the SHA-256 of the file a mesh was loaded from, in
+// hex, or "" for derived meshes and unknown IDs.
+func (a *App) GetModelHash(meshID string) string {
+	m, err := a.meshes.get(meshID)
+	if err != nil || m.Meta == nil {
+		return ""
+	}
+	return m.Meta.Hash
+}
+
+// fileHash is a file's content hash with the size and modification time it
+// was taken at.
+type fileHash struct {
+	size int64
+	mod  time.Time
+	sum  string
+}
+
+// modelHash returns the content hash of the file at abs, taken from a loaded
+// copy when there is one, or "" if the file cannot be read. Other files are
+// hashed once and then again only when their size or modification time
+// changes.
+func (a *App) modelHash(abs string) string {
+	if m, ok := a.meshes.lookupPath(abs); ok && m.Meta != nil {
+		return m.Meta.Hash
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return ""
+	}
+	a.hashMu.Lock()
+	cached, ok := a.fileHashes[abs]
+	a.hashMu.Unlock()
+	if ok && cached.size == info.Size() && cached.mod.Equal(info.ModTime()) {
+		return cached.sum
+	}
+
+	sum, err := hashFile(abs)
+	if err != nil {
+		return ""
+	}
+	a.hashMu.Lock()
+	if a.fileHashes == nil {
+		a.fileHashes = make(map[string]fileHash)
+	}
+	a.fileHashes[abs] = fileHash{size: info.Size(), mod: info.ModTime(), sum: sum}
+	a.hashMu.Unlock()
+	return sum
+}
+
+// modelKey is the settings key for per-model data: the content hash when it
+// is known, so the data follows the file when it moves, or else the path.
+func modelKey(path, sum string) string {
+	if sum != "" {
+		return "sha256:" + sum
+	}
+	return path
+}
+
+// lookupModel finds per-model data by content hash, then by path.
+func lookupModel[T any](data map[string]T, path, sum string) (T, string, bool) {
+	for _, key := range []string{modelKey(path, sum), path} {
+		if v, ok := data[key]; ok {
+			return v, key, true
+		}
+	}
+	var zero T
+	return zero, "", false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestModelHashCachesBySizeAndTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "part.stl")
+	stamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	write := func(content string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := func(content string) string {
+		t.Helper()
+		dir := t.TempDir()
+		p := filepath.Join(dir, "f")
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		sum, err := hashFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+	a := NewApp(defaultSettings())
+
+	write("solid one", stamp)
+	first := a.modelHash(path)
+	if first != want("solid one") {
+		t.Fatalf("hash = %q, want the file's digest", first)
+	}
+	// Same size and time: the cached digest is returned without rereading.
+	write("solid two", stamp)
+	if got := a.modelHash(path); got != first {
+		t.Errorf("hash after an unseen edit = %q, want the cached %q", got, first)
+	}
+	write("solid two", stamp.Add(time.Second))
+	if got := a.modelHash(path); got != want("solid two") {
+		t.Errorf("hash after a new modification time = %q, want the new digest", got)
+	}
+	if got := a.modelHash(filepath.Join(t.TempDir(), "missing.stl")); got != "" {
+		t.Errorf("hash of a missing file = %q, want empty", got)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// defaultHeavyThreshold is the triangle count above which a model triggers
+// model:heavy. Most integrated GPUs stay interactive below it.
+const defaultHeavyThreshold = 5_000_000
+
+// GetHeavyThreshold returns the triangle count above which a loaded model is
+// reported as heavy.
+func (a *App) GetHeavyThreshold() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.settings.HeavyThreshold <= 0 {
+		return defaultHeavyThreshold
+	}
+	return a.settings.HeavyThreshold
+}
+
+// SetHeavyThreshold stores the triangle count above which models are
+// reported as heavy.
+func (a *App) SetHeavyThreshold(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("heavy threshold must be positive, got %d", n)
+	}
+	a.mu.Lock()
+	a.settings.HeavyThreshold = n
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+// warnIfHeavy emits model:heavy when a freshly parsed model exceeds the
+// threshold. It is only a warning: the model is loaded regardless, and the
+// event goes out before model:loaded so the frontend can ask whether to
+// display or simplify it.
+func (a *App) warnIfHeavy(path string, triangles int) {
+	threshold := a.GetHeavyThreshold()
+	if triangles <= threshold {
+		return
+	}
+	appLog.warn("heavy model loaded", "path", path, "triangles", triangles, "threshold", threshold)
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelHeavy, path, triangles, threshold)
+	}
+}
+
+// triangleCount sums the triangles of every primitive in s.
+func (s *Scene) triangleCount() int {
+	n := 0
+	for _, m := range s.Meshes {
+		for _, p := range m.Primitives {
+			n += p.Mesh.TriangleCount()
+		}
+	}
+	return n
+}
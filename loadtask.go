@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// LoadAsync starts loading path in the background and returns a task ID at
+// once. Progress and the outcome arrive as load:progress, load:complete and
+// load:error events tagged with that ID, or load:cancelled after CancelLoad.
+// Unsupported or missing files are reported here instead.
+func (a *App) LoadAsync(path string) (string, error) {
+	if _, err := formatForPath(path); err != nil {
+		return "", fmt.Errorf("load: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	if a.loads == nil {
+		a.loads = make(map[string]context.CancelFunc)
+	}
+	a.nextLoad++
+	taskID := fmt.Sprintf("load-%d", a.nextLoad)
+	a.loads[taskID] = cancel
+	a.mu.Unlock()
+
+	go a.runLoad(ctx, taskID, path)
+	return taskID, nil
+}
+
+// CancelLoad stops a load started with LoadAsync. The loader gives up at its
+// next read and the mesh is never registered.
+func (a *App) CancelLoad(taskID string) error {
+	a.mu.Lock()
+	cancel, ok := a.loads[taskID]
+	a.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("load: no running task %q", taskID)
+	}
+	cancel()
+	return nil
+}
+
+func (a *App) runLoad(ctx context.Context, taskID, path string) {
+	defer func() {
+		a.mu.Lock()
+		cancel := a.loads[taskID]
+		delete(a.loads, taskID)
+		a.mu.Unlock()
+		cancel()
+	}()
+
+	mesh, err := a.openModel(ctx, path, newTaskProgressEmitter(a.ctx, taskID, path).report)
+	switch {
+	case errors.Is(err, context.Canceled):
+		runtime.EventsEmit(a.ctx, eventLoadCancelled, taskID, path)
+	case err != nil:
+		runtime.EventsEmit(a.ctx, eventLoadError, taskID, path, err.Error())
+	default:
+		runtime.EventsEmit(a.ctx, eventLoadComplete, taskID, path, mesh.descriptor())
+	}
+}
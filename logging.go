@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+const (
+	// logLevelEnv selects the minimum level, e.g. S3DV_LOG=debug.
+	logLevelEnv = "S3DV_LOG"
+	// maxLogSize is the size at which the log file is rotated to a single
+	// ".1" backup.
+	maxLogSize = 5 << 20
+)
+
+// appLog is the process-wide logger. Until openAppLogger runs it writes to
+// stderr only.
+var appLog = &appLogger{level: logger.INFO, stderr: os.Stderr}
+
+// appLogger writes leveled lines of the form
+//
+//	2006-01-02T15:04:05.000Z07:00 INFO message key=value ...
+//
+// to stderr and a size-capped file. It implements logger.Logger so Wails'
+// own messages end up in the same file.
+type appLogger struct {
+	mu     sync.Mutex
+	level  logger.LogLevel
+	stderr io.Writer
+	path   string
+	file   *os.File
+	size   int64
+}
+
+// openAppLogger creates the log file under the config directory and reads the
+// level from S3DV_LOG. Failing to open the file leaves stderr logging in
+// place.
+func openAppLogger() *appLogger {
+	l := &appLogger{level: logger.INFO, stderr: os.Stderr}
+	if env := os.Getenv(logLevelEnv); env != "" {
+		level, err := logger.StringToLogLevel(env)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid %s value %q, using info\n", logLevelEnv, env)
+		} else {
+			l.level = level
+		}
+	}
+
+	dir, err := configDir()
+	if err != nil {
+		return l
+	}
+	l.path = filepath.Join(dir, "logs", "viewer.log")
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		l.path = ""
+		return l
+	}
+	if err := l.open(); err != nil {
+		fmt.Fprintln(os.Stderr, "cannot open log file:", err)
+		l.path = ""
+	}
+	return l
+}
+
+func (l *appLogger) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// rotate moves the current file to the backup slot and starts a new one.
+// Callers hold l.mu.
+func (l *appLogger) rotate() {
+	l.file.Close()
+	l.file = nil
+	backup := l.path + ".1"
+	// Windows refuses to rename over an existing file.
+	os.Remove(backup)
+	os.Rename(l.path, backup)
+	if err := l.open(); err != nil {
+		fmt.Fprintln(l.stderr, "cannot reopen log file:", err)
+	}
+}
+
+// log formats msg with alternating key/value pairs and writes it if level is
+// enabled.
+func (l *appLogger) log(level logger.LogLevel, msg string, kv ...any) {
+	if level >= l.level {
+		l.write(level, msg, kv...)
+	}
+}
+
+func (l *appLogger) write(level logger.LogLevel, msg string, kv ...any) {
+	var b strings.Builder
+	b.WriteString(time.Now().Format("2006-01-02T15:04:05.000Z07:00"))
+	b.WriteByte(' ')
+	b.WriteString(strings.ToUpper(level.String()))
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fmt.Fprintf(&b, " %v=%s", kv[i], logValue(kv[i+1]))
+	}
+	b.WriteByte('\n')
+	line := b.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.stderr, line)
+	if l.file == nil {
+		return
+	}
+	if l.size+int64(len(line)) > maxLogSize {
+		l.rotate()
+		if l.file == nil {
+			return
+		}
+	}
+	n, _ := io.WriteString(l.file, line)
+	l.size += int64(n)
+}
+
+// logValue quotes values containing spaces or quotes so lines stay parseable.
+func logValue(v any) string {
+	var s string
+	switch v := v.(type) {
+	case error:
+		s = v.Error()
+	case time.Duration:
+		s = v.Round(time.Microsecond).String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func (l *appLogger) debug(msg string, kv ...any) { l.log(logger.DEBUG, msg, kv...) }
+func (l *appLogger) info(msg string, kv ...any)  { l.log(logger.INFO, msg, kv...) }
+func (l *appLogger) warn(msg string, kv ...any)  { l.log(logger.WARNING, msg, kv...) }
+func (l *appLogger) error(msg string, kv ...any) { l.log(logger.ERROR, msg, kv...) }
+
+// The logger.Logger methods below receive Wails' own messages.
+
+// Print is unconditional in Wails' default logger, so it bypasses the level.
+func (l *appLogger) Print(message string)   { l.write(logger.INFO, message) }
+func (l *appLogger) Trace(message string)   { l.log(logger.TRACE, message) }
+func (l *appLogger) Debug(message string)   { l.log(logger.DEBUG, message) }
+func (l *appLogger) Info(message string)    { l.log(logger.INFO, message) }
+func (l *appLogger) Warning(message string) { l.log(logger.WARNING, message) }
+func (l *appLogger) Error(message string)   { l.log(logger.ERROR, message) }
+
+func (l *appLogger) Fatal(message string) {
+	l.log(logger.ERROR, message)
+	os.Exit(1)
+}
+
+// GetLogPath returns the path of the current log file, or an empty string
+// when logging to a file is unavailable. The folder containing it is what
+// "Open log folder" should reveal.
+func (a *App) GetLogPath() string {
+	return appLog.path
+}
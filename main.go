@@ -2,6 +2,9 @@ package main
 
 import (
 	"embed"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -12,25 +15,67 @@ import (
 var assets embed.FS
 
 func main() {
+	appLog = openAppLogger()
+	settings := loadSettings()
+	width, height := settings.Window.windowSize()
+
 	// Create an instance of the app structure
-	app := NewApp()
+	app := NewApp(settings)
+	if wd, err := os.Getwd(); err == nil {
+		app.startupPath = modelPathFromArgs(os.Args[1:], wd)
+	}
 
 	// Create application with options
 	err := wails.Run(&options.App{
-		Title:  "Simple 3D Viewer",
-		Width:  1024,
-		Height: 768,
+		Title:            "Simple 3D Viewer",
+		Width:            width,
+		Height:           height,
+		Frameless:        appFrameless,
+		WindowStartState: settings.Window.startState(),
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: newMeshBufferHandler(app.meshes),
+		},
+		// Wails' lock is an OS-level primitive (mutex, D-Bus name or
+		// notification centre), so it is released even if the process
+		// crashes.
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               "com.eugene.simple3dviewer",
+			OnSecondInstanceLaunch: app.onSecondInstanceLaunch,
+		},
+		DragAndDrop: &options.DragAndDrop{
+			EnableFileDrop: true,
 		},
-		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
-		OnStartup:        app.startup,
+		Logger:             appLog,
+		LogLevel:           appLog.level,
+		LogLevelProduction: appLog.level,
+		Menu:               app.buildMenu(),
+		BackgroundColour:   settings.viewerPrefs().backgroundColour(),
+		OnStartup:          app.startup,
+		OnDomReady:         app.domReady,
+		OnBeforeClose:      app.beforeClose,
 		Bind: []interface{}{
 			app,
 		},
 	})
 
 	if err != nil {
-		println("Error:", err.Error())
+		appLog.error("application failed", "error", err)
+	}
+}
+
+// modelPathFromArgs returns the first non-flag argument, resolved against
+// workDir. Whether the file exists and is supported is reported to the
+// frontend later.
+func modelPathFromArgs(args []string, workDir string) string {
+	for _, arg := range args {
+		if arg == "" || strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if filepath.IsAbs(arg) {
+			return filepath.Clean(arg)
+		}
+		return filepath.Join(workDir, arg)
 	}
+	return ""
 }
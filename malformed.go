@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// maxMalformedFraction is the share of data records that may be skipped
+	// before a load is failed outright: past it the file is more likely in
+	// the wrong format than lightly damaged.
+	maxMalformedFraction = 0.2
+	// maxParseWarnings caps the warnings kept on a model.
+	maxParseWarnings = 20
+)
+
+// parseIssues tallies the records a text parser has read and the malformed
+// ones it skipped.
+type parseIssues struct {
+	records  int
+	skipped  int
+	warnings []string
+}
+
+func (p *parseIssues) skip(where string, err error) {
+	p.skipped++
+	if len(p.warnings) < maxParseWarnings {
+		p.warnings = append(p.warnings, fmt.Sprintf("%s: %v", where, err))
+	}
+}
+
+// err fails the parse once too many records were skipped.
+func (p *parseIssues) err() error {
+	if p.skipped == 0 || float64(p.skipped) <= maxMalformedFraction*float64(p.records) {
+		return nil
+	}
+	return fmt.Errorf("%d of %d records are malformed, first at %s", p.skipped, p.records, p.warnings[0])
+}
+
+// report records the skipped records on the mesh's metadata.
+func (p *parseIssues) report(m *Mesh) {
+	if p.skipped == 0 {
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = &ModelMeta{}
+	}
+	m.Meta.SkippedRecords = p.skipped
+	m.Meta.Warnings = p.warnings
+	if p.skipped > len(p.warnings) {
+		m.Meta.Warnings = append(m.Meta.Warnings, fmt.Sprintf("%d more not shown", p.skipped-len(p.warnings)))
+	}
+}
+
+// fatalParseError marks an error that fails the load instead of being
+// skipped: a missing material library or texture is not a damaged record.
+type fatalParseError struct {
+	err error
+}
+
+func (e fatalParseError) Error() string { return e.err.Error() }
+func (e fatalParseError) Unwrap() error { return e.err }
+
+// isFinite reports whether v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
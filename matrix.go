@@ -0,0 +1,117 @@
+package main
+
+import "math"
+
+// mat4 is a column-major 4x4 matrix, matching glTF and WebGL conventions.
+type mat4 [16]float64
+
+func identityMat4() mat4 {
+	return mat4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+}
+
+func translationMat4(t [3]float64) mat4 {
+	return mat4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, t[0], t[1], t[2], 1}
+}
+
+// trsMat4 composes translation, rotation (unit quaternion x,y,z,w) and scale.
+func trsMat4(t [3]float64, q [4]float64, s [3]float64) mat4 {
+	x, y, z, w := q[0], q[1], q[2], q[3]
+	return mat4{
+		(1 - 2*(y*y+z*z)) * s[0], 2 * (x*y + z*w) * s[0], 2 * (x*z - y*w) * s[0], 0,
+		2 * (x*y - z*w) * s[1], (1 - 2*(x*x+z*z)) * s[1], 2 * (y*z + x*w) * s[1], 0,
+		2 * (x*z + y*w) * s[2], 2 * (y*z - x*w) * s[2], (1 - 2*(x*x+y*y)) * s[2], 0,
+		t[0], t[1], t[2], 1,
+	}
+}
+
+// mul returns m * n.
+func (m mat4) mul(n mat4) mat4 {
+	var out mat4
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			var sum float64
+			for k := 0; k < 4; k++ {
+				sum += m[k*4+row] * n[col*4+k]
+			}
+			out[col*4+row] = sum
+		}
+	}
+	return out
+}
+
+// isTranslation reports whether m's upper 3x3 block is the identity.
+func (m mat4) isTranslation() bool {
+	id := identityMat4()
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			if m[col*4+row] != id[col*4+row] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// det3 returns the determinant of the upper 3x3 block, which is negative
+// for transforms that mirror.
+func (m mat4) det3() float64 {
+	return m[0]*(m[5]*m[10]-m[9]*m[6]) - m[4]*(m[1]*m[10]-m[9]*m[2]) + m[8]*(m[1]*m[6]-m[5]*m[2])
+}
+
+// affineInverse inverts m, assuming a bottom row of 0 0 0 1, or returns
+// false when the upper 3x3 block is singular.
+func (m mat4) affineInverse() (mat4, bool) {
+	n, ok := m.normalMatrix()
+	if !ok {
+		return mat4{}, false
+	}
+	// The inverse of the 3x3 block is the transpose of the normal matrix,
+	// and the offset is the inverted block applied to the negated offset.
+	var inv mat4
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			inv[col*4+row] = n[row*3+col]
+		}
+	}
+	for row := 0; row < 3; row++ {
+		inv[12+row] = -(inv[row]*m[12] + inv[4+row]*m[13] + inv[8+row]*m[14])
+	}
+	inv[15] = 1
+	return inv, true
+}
+
+func (m mat4) transformPoint(p [3]float64) [3]float64 {
+	return [3]float64{
+		m[0]*p[0] + m[4]*p[1] + m[8]*p[2] + m[12],
+		m[1]*p[0] + m[5]*p[1] + m[9]*p[2] + m[13],
+		m[2]*p[0] + m[6]*p[1] + m[10]*p[2] + m[14],
+	}
+}
+
+// normalMatrix returns the inverse-transpose of the upper 3x3 block in
+// column-major order, or false when that block is singular.
+func (m mat4) normalMatrix() ([9]float64, bool) {
+	a, b, c := m[0], m[4], m[8]
+	d, e, f := m[1], m[5], m[9]
+	g, h, i := m[2], m[6], m[10]
+
+	det := a*(e*i-f*h) - b*(d*i-f*g) + c*(d*h-e*g)
+	if math.Abs(det) < 1e-12 {
+		return [9]float64{}, false
+	}
+	inv := 1 / det
+	// The transpose of the inverse is the cofactor matrix divided by det.
+	return [9]float64{
+		(e*i - f*h) * inv, -(b*i - c*h) * inv, (b*f - c*e) * inv,
+		-(d*i - f*g) * inv, (a*i - c*g) * inv, -(a*f - c*d) * inv,
+		(d*h - e*g) * inv, -(a*h - b*g) * inv, (a*e - b*d) * inv,
+	}, true
+}
+
+func transformNormal(n [9]float64, v [3]float64) [3]float64 {
+	return normalize([3]float64{
+		n[0]*v[0] + n[3]*v[1] + n[6]*v[2],
+		n[1]*v[0] + n[4]*v[1] + n[7]*v[2],
+		n[2]*v[0] + n[5]*v[1] + n[8]*v[2],
+	})
+}
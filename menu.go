@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// buildMenu creates the application menu bar. It is built before the window
+// exists, so every callback defers to a.ctx, which is set by startup before
+// any item can be clicked.
+func (a *App) buildMenu() *menu.Menu {
+	bar := menu.NewMenu()
+	if goruntime.GOOS == "darwin" {
+		bar.Append(menu.AppMenu())
+	}
+
+	file := bar.AddSubmenu("File")
+	file.AddText("Open…", keys.CmdOrCtrl("o"), func(*menu.CallbackData) {
+		go a.openFromMenu()
+	})
+	a.recentItem = menu.SubMenu("Open Recent", a.recentSubmenu())
+	file.Append(a.recentItem)
+	file.AddSeparator()
+	file.AddText("Export…", keys.CmdOrCtrl("e"), a.emitMenuEvent(eventMenuExport))
+	file.AddSeparator()
+	file.AddText("Quit", keys.CmdOrCtrl("q"), func(*menu.CallbackData) {
+		runtime.Quit(a.ctx)
+	})
+
+	if goruntime.GOOS == "darwin" {
+		// Without the Edit menu, copy and paste shortcuts do not reach the
+		// webview on macOS.
+		bar.Append(menu.EditMenu())
+	}
+
+	view := bar.AddSubmenu("View")
+	view.AddText("Reset Camera", keys.CmdOrCtrl("0"), a.emitMenuEvent(eventViewResetCamera))
+	view.AddText("Toggle Wireframe", keys.Combo("w", keys.CmdOrCtrlKey, keys.ShiftKey), a.emitMenuEvent(eventViewToggleWireframe))
+
+	help := bar.AddSubmenu("Help")
+	help.AddText("Open Log Folder", nil, func(*menu.CallbackData) {
+		a.openLogFolder()
+	})
+	help.AddText("About", nil, func(*menu.CallbackData) {
+		go a.showAbout()
+	})
+	return bar
+}
+
+// emitMenuEvent returns a callback that forwards a menu click to the
+// frontend, for actions that depend on state only the viewer holds.
+func (a *App) emitMenuEvent(name string) menu.Callback {
+	return func(*menu.CallbackData) {
+		runtime.EventsEmit(a.ctx, name)
+	}
+}
+
+// recentSubmenu builds an Open Recent submenu from the recent-files list.
+func (a *App) recentSubmenu() *menu.Menu {
+	a.mu.Lock()
+	files := append([]RecentFile(nil), a.settings.RecentFiles...)
+	a.mu.Unlock()
+
+	items := menu.NewMenu()
+	if len(files) == 0 {
+		items.AddText("No Recent Files", nil, nil).Disabled = true
+		return items
+	}
+	for _, f := range files {
+		path := f.Path
+		items.AddText(f.Name, nil, func(*menu.CallbackData) {
+			go a.loadAndEmit(path)
+		})
+	}
+	items.AddSeparator()
+	items.AddText("Clear Recent", nil, func(*menu.CallbackData) {
+		if err := a.ClearRecentFiles(); err != nil {
+			appLog.error("clearing recent files failed", "error", err)
+		}
+	})
+	return items
+}
+
+// refreshRecentMenu swaps in a freshly built Open Recent submenu after the
+// recent list changes and pushes the new menu to the window. The submenu
+// is built under menuMu too, so the last swap always holds the latest list.
+func (a *App) refreshRecentMenu() {
+	if a.recentItem == nil {
+		return
+	}
+	a.menuMu.Lock()
+	defer a.menuMu.Unlock()
+	a.recentItem.SubMenu = a.recentSubmenu()
+	if a.ctx != nil {
+		runtime.MenuUpdateApplicationMenu(a.ctx)
+	}
+}
+
+// openFromMenu asks for a model and loads it like a dropped file, reporting
+// the outcome through runtime events.
+func (a *App) openFromMenu() {
+	path, err := a.chooseModelPath()
+	if errors.Is(err, ErrDialogCancelled) {
+		return
+	}
+	if err != nil {
+		appLog.error("open dialog failed", "error", err)
+		return
+	}
+	a.loadAndEmit(path)
+}
+
+// openLogFolder reveals the folder holding the log file in the system file
+// manager.
+func (a *App) openLogFolder() {
+	if appLog.path == "" {
+		appLog.warn("no log file to reveal")
+		return
+	}
+	dir := filepath.ToSlash(filepath.Dir(appLog.path))
+	if !strings.HasPrefix(dir, "/") {
+		dir = "/" + dir
+	}
+	runtime.BrowserOpenURL(a.ctx, (&url.URL{Scheme: "file", Path: dir}).String())
+}
+
+func (a *App) showAbout() {
+	var names []string
+	for _, f := range modelFormats {
+		names = append(names, f.name)
+	}
+	v := a.GetVersionInfo()
+	_, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:  runtime.InfoDialog,
+		Title: "About Simple 3D Viewer",
+		Message: fmt.Sprintf("Simple 3D Viewer %s\nCommit %s, built %s\n%s %s/%s\n\nSupported formats: %s.",
+			v.Version, v.GitCommit, v.BuildDate, v.GoVersion, v.OS, v.Arch, strings.Join(names, ", ")),
+	})
+	if err != nil {
+		appLog.error("about dialog failed", "error", err)
+	}
+}
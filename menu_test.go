@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestRecentMenuConcurrentRefresh(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	a := NewApp(defaultSettings())
+	a.buildMenu()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.addRecentFile(filepath.Join(dir, fmt.Sprintf("m%d.obj", i)), "")
+		}(i)
+	}
+	wg.Wait()
+
+	// One item per file plus the separator and Clear Recent.
+	if got, want := len(a.recentItem.SubMenu.Items), len(a.GetRecentFiles())+2; got != want {
+		t.Errorf("Open Recent has %d items, want %d", got, want)
+	}
+}
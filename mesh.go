@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Mesh is decoded triangle geometry in flat buffers ready for upload to WebGL.
+// Vertices, Normals and UVs are indexed by Indices; Normals and UVs are empty
+// when the source file does not provide them.
+type Mesh struct {
+	// ID identifies the mesh in later calls such as ComputeStats.
+	ID string `json:"id"`
+
+	Vertices  []float32  `json:"vertices"`
+	Normals   []float32  `json:"normals"`
+	UVs       []float32  `json:"uvs"`
+	Indices   []uint32   `json:"indices"`
+	Materials []Material `json:"materials"`
+	// Lines holds pairs of vertex indices, one pair per line segment, and
+	// Points single vertex indices to draw as markers. Both index the same
+	// vertex buffer as the triangles.
+	Lines  []uint32 `json:"lines,omitempty"`
+	Points []uint32 `json:"points,omitempty"`
+	// Colors holds optional per-vertex RGBA values in [0,1].
+	Colors []float32 `json:"colors,omitempty"`
+	// PointCloud is set when the source has vertices but no faces, so the
+	// frontend should draw points instead of triangles.
+	PointCloud bool `json:"pointCloud,omitempty"`
+
+	// FlipV is set when V has been flipped from the file's convention by
+	// SetFlipV.
+	FlipV bool `json:"flipV,omitempty"`
+
+	// Transform is the transform baked into Vertices and Normals since
+	// loading, or nil.
+	Transform *Transform `json:"transform,omitempty"`
+
+	// Units is the length unit of the coordinates, one of "mm", "cm", "m"
+	// or "in".
+	Units string `json:"units"`
+	// Meta describes the source file; it is nil for derived meshes such as
+	// simplified copies.
+	Meta *ModelMeta `json:"meta,omitempty"`
+
+	// Groups lists the named parts of the mesh, such as OBJ objects and
+	// groups, as ranges of Indices. It is empty for formats without parts.
+	Groups []SubMesh `json:"groups,omitempty"`
+
+	// Attributes holds the per-triangle attribute byte count of binary STL
+	// files; some exporters store a colour there. Nil when all are zero.
+	Attributes []uint16 `json:"attributes,omitempty"`
+
+	// buffersMu guards the geometry, units, V convention and transform of a
+	// registered mesh. The methods that rewrite them in place hold it for
+	// writing; App methods that read them hold it for reading around the
+	// work, as does the asset server while streaming a buffer. The helpers
+	// they call take no lock of their own, and the lock is never held while
+	// calling into the mesh registry, which takes it for size accounting.
+	buffersMu sync.RWMutex
+}
+
+// MarshalJSON encodes m under buffersMu, so a mesh returned to the frontend
+// is not encoded while another call edits it.
+func (m *Mesh) MarshalJSON() ([]byte, error) {
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	type plain Mesh
+	return json.Marshal((*plain)(m))
+}
+
+// Material is the subset of surface properties the viewer understands.
+// Texture paths are absolute.
+type Material struct {
+	Name         string     `json:"name"`
+	DiffuseColor [3]float32 `json:"diffuseColor"`
+	Opacity      float32    `json:"opacity"`
+	DiffuseMap   string     `json:"diffuseMap,omitempty"`
+	BumpMap      string     `json:"bumpMap,omitempty"`
+	// Metallic and Roughness are the glTF metallic-roughness factors, nil
+	// for formats without them.
+	Metallic  *float32 `json:"metallic,omitempty"`
+	Roughness *float32 `json:"roughness,omitempty"`
+}
+
+// SubMesh is a run of triangles sharing a name and material. Start and Count
+// are offsets into Mesh.Indices, so both are multiples of three. Material
+// indexes Mesh.Materials, or is -1 when none applies.
+type SubMesh struct {
+	Name     string `json:"name"`
+	Start    int    `json:"start"`
+	Count    int    `json:"count"`
+	Material int    `json:"material"`
+}
+
+// derived returns an empty mesh with m's materials, unit, V convention and
+// baked transform, for operations that build new geometry from m.
+func (m *Mesh) derived() *Mesh {
+	out := &Mesh{Materials: m.Materials, Units: m.Units, FlipV: m.FlipV}
+	if m.Transform != nil {
+		t := *m.Transform
+		out.Transform = &t
+	}
+	return out
+}
+
+// groupCursor rebuilds a mesh's groups as runs over a copy of some of its
+// triangles. Source triangles must be added in increasing order.
+type groupCursor struct {
+	src  []SubMesh
+	next int
+}
+
+// add assigns the triangle copied from source triangle t, starting at index
+// start of out.Indices, to the group t belonged to.
+func (c *groupCursor) add(out *Mesh, t, start int) {
+	for c.next < len(c.src) && c.src[c.next].Start+c.src[c.next].Count <= t*3 {
+		c.next++
+	}
+	if c.next >= len(c.src) || c.src[c.next].Start > t*3 {
+		return
+	}
+	g := c.src[c.next]
+	if n := len(out.Groups); n > 0 && out.Groups[n-1].Name == g.Name && out.Groups[n-1].Material == g.Material &&
+		out.Groups[n-1].Start+out.Groups[n-1].Count == start {
+		out.Groups[n-1].Count += 3
+		return
+	}
+	out.Groups = append(out.Groups, SubMesh{Name: g.Name, Start: start, Count: 3, Material: g.Material})
+}
+
+// hasVertexColors reports whether Colors holds one RGBA value per vertex.
+func (m *Mesh) hasVertexColors() bool {
+	return len(m.Colors) > 0 && len(m.Colors) == m.VertexCount()*4
+}
+
+// VertexCount returns the number of vertices in the mesh.
+func (m *Mesh) VertexCount() int {
+	return len(m.Vertices) / 3
+}
+
+// TriangleCount returns the number of triangles in the mesh.
+func (m *Mesh) TriangleCount() int {
+	return len(m.Indices) / 3
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxMetaComments caps the comments kept from a file header so a file made
+// mostly of comments does not bloat every response.
+const maxMetaComments = 64
+
+// ModelMeta describes the file a model came from.
+type ModelMeta struct {
+	Path    string  `json:"path"`
+	Size    int64   `json:"size"`
+	Format  string  `json:"format"`
+	ParseMs float64 `json:"parseMs"`
+	// Hash is the hex SHA-256 of the file's bytes, which identifies the
+	// content wherever the file is moved.
+	Hash string `json:"hash,omitempty"`
+	// Header is free text from a fixed header, such as the 80-byte header
+	// of binary STL or the name after "solid" in ASCII STL.
+	Header string `json:"header,omitempty"`
+	// Comments holds header comments: PLY comment lines, leading OBJ #
+	// lines, glTF asset info and 3MF metadata.
+	Comments []string `json:"comments,omitempty"`
+	// SkippedRecords counts malformed lines or elements that were dropped
+	// while parsing, and Warnings describes them, up to a limit.
+	SkippedRecords int      `json:"skippedRecords,omitempty"`
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// describeFile completes meta, which the parser may have started with
+// header text, with file details and the parse time.
+func describeFile(meta *ModelMeta, path, format string, elapsed time.Duration) *ModelMeta {
+	if meta == nil {
+		meta = &ModelMeta{}
+	}
+	meta.Path = path
+	if abs, err := filepath.Abs(path); err == nil {
+		meta.Path = abs
+	}
+	if info, err := os.Stat(path); err == nil {
+		meta.Size = info.Size()
+	}
+	meta.Format = format
+	meta.ParseMs = float64(elapsed.Microseconds()) / 1000
+	return meta
+}
+
+func (m *ModelMeta) addComment(text string) {
+	if text = cleanMetaText(text); text != "" && len(m.Comments) < maxMetaComments {
+		m.Comments = append(m.Comments, text)
+	}
+}
+
+// cleanMetaText drops control characters and surrounding padding, which
+// binary headers are full of.
+func cleanMetaText(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, s))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+)
+
+// LoadMultiple loads several files side by side, for example the parts of
+// an assembly. Each file becomes a root node whose single primitive is the
+// registered mesh, so parts keep their own IDs and can be hidden or unloaded
+// individually. The node scales its part from the part's units to metres, so
+// parts of mixed units line up; parts of unknown units count as metres.
+// Files that fail are listed in Scene.Errors; the call only fails if none
+// could be loaded. Scene.Bounds encloses every scaled part.
+func (a *App) LoadMultiple(paths []string) (*Scene, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("load multiple: no files given")
+	}
+
+	s := &Scene{
+		Nodes:     []SceneNode{},
+		Roots:     []int{},
+		Meshes:    []SceneMesh{},
+		Materials: []PBRMaterial{},
+		Textures:  []SceneTexture{},
+		Images:    []SceneImage{},
+	}
+	var errs []error
+	for _, path := range paths {
+		m, err := a.openModel(context.Background(), path, nil)
+		if err != nil {
+			s.Errors = append(s.Errors, FileError{Path: path, Error: err.Error()})
+			errs = append(errs, err)
+			continue
+		}
+		s.addPart(filepath.Base(path), m)
+	}
+	if len(s.Meshes) == 0 {
+		return nil, fmt.Errorf("load multiple: %w", errors.Join(errs...))
+	}
+	return s, nil
+}
+
+// addPart appends m as a new root node scaled to metres and grows the scene
+// bounds to fit it.
+func (s *Scene) addPart(name string, m *Mesh) {
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	mode := gltfTriangles
+	if m.PointCloud {
+		mode = gltfPoints
+	}
+	s.Meshes = append(s.Meshes, SceneMesh{
+		Name:       name,
+		Primitives: []Primitive{{Mesh: m, Material: -1, Mode: mode}},
+	})
+	f := 1.0
+	if u, ok := unitMetres[m.Units]; ok {
+		f = u
+	}
+	s.Roots = append(s.Roots, len(s.Nodes))
+	s.Nodes = append(s.Nodes, SceneNode{
+		Name:     name,
+		Children: []int{},
+		Mesh:     len(s.Meshes) - 1,
+		Rotation: [4]float64{0, 0, 0, 1},
+		Scale:    [3]float64{f, f, f},
+	})
+
+	if m.VertexCount() == 0 {
+		return
+	}
+	lo, hi := m.bounds()
+	lo, hi = scale(lo, f), scale(hi, f)
+	if s.Bounds == nil {
+		s.Bounds = &Bounds{Min: lo, Max: hi}
+		return
+	}
+	for i := 0; i < 3; i++ {
+		s.Bounds.Min[i] = math.Min(s.Bounds.Min[i], lo[i])
+		s.Bounds.Max[i] = math.Max(s.Bounds.Max[i], hi[i])
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAddPartScalesMixedUnitsToMetres(t *testing.T) {
+	tri := func(units string, v ...float32) *Mesh {
+		return &Mesh{Vertices: v, Indices: []uint32{0, 1, 2}, Units: units}
+	}
+	s := &Scene{}
+	s.addPart("bolt", tri("mm", 0, 0, 0, 500, 250, 0, 0, 0, 100))
+	s.addPart("plate", tri("m", -1, -1, 0, 0.25, 0, 0, 0, 0, 0))
+	s.addPart("loose", tri("", 0, 0, -2, 0, 0, 0, 0, 0.1, 0))
+
+	if got := s.Nodes[0].Scale; got != [3]float64{0.001, 0.001, 0.001} {
+		t.Errorf("mm part scale = %v, want 0.001", got)
+	}
+	if s.Bounds == nil {
+		t.Fatal("no bounds")
+	}
+	// The bounds must enclose the geometry exactly as the nodes place it.
+	lo, hi := s.flatten().bounds()
+	for k := 0; k < 3; k++ {
+		if math.Abs(s.Bounds.Min[k]-lo[k]) > 1e-6 || math.Abs(s.Bounds.Max[k]-hi[k]) > 1e-6 {
+			t.Fatalf("bounds = %+v, transformed geometry spans %v to %v", *s.Bounds, lo, hi)
+		}
+	}
+	if want := [3]float64{0.5, 0.25, 0.1}; math.Abs(hi[0]-want[0]) > 1e-6 || math.Abs(hi[1]-want[1]) > 1e-6 || math.Abs(hi[2]-want[2]) > 1e-6 {
+		t.Errorf("max = %v, want %v", hi, want)
+	}
+}
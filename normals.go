@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// RecomputeNormals regenerates vertex normals for a loaded mesh. With smooth
+// set, each vertex gets the area-weighted average of the faces around it;
+// otherwise vertices are split so every triangle is flat shaded. Meshes that
+// already carry normals are left alone unless force is set. Point clouds
+// have no faces to take normals from and are rejected. model:updated carries
+// the mesh afterwards.
+func (a *App) RecomputeNormals(meshID string, smooth bool, force bool) error {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	m.buffersMu.RLock()
+	triangles, hasNormals := !m.PointCloud && m.TriangleCount() > 0, len(m.Normals) > 0
+	m.buffersMu.RUnlock()
+	if !triangles {
+		return fmt.Errorf("recompute normals: mesh %q has no triangles", meshID)
+	}
+	if hasNormals && !force {
+		return nil
+	}
+	if smooth {
+		m.smoothNormals()
+	} else {
+		m.flatNormals()
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelUpdated, meshID, m.descriptor())
+	}
+	return nil
+}
+
+// smoothNormals sums unnormalised face normals, whose length is twice the
+// triangle area, so larger faces weigh more. Vertices sharing a position are
+// accumulated together so UV seams do not show up as shading seams.
+func (m *Mesh) smoothNormals() {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	canon := m.positionClasses()
+	sums := make([][3]float64, m.VertexCount())
+	for t := 0; t < m.TriangleCount(); t++ {
+		p0, p1, p2 := m.triangle(t)
+		n := cross(sub(p1, p0), sub(p2, p0))
+		for k := 0; k < 3; k++ {
+			c := canon[m.Indices[t*3+k]]
+			sums[c] = add(sums[c], n)
+		}
+	}
+
+	m.Normals = make([]float32, len(m.Vertices))
+	for v := range sums {
+		n := normalize(sums[canon[v]])
+		m.Normals[v*3], m.Normals[v*3+1], m.Normals[v*3+2] = float32(n[0]), float32(n[1]), float32(n[2])
+	}
+}
+
+// flatNormals gives every triangle its own three vertices carrying the face
+// normal, copying the other per-vertex attributes across.
+func (m *Mesh) flatNormals() {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	count := len(m.Indices)
+	vertices := make([]float32, 0, count*3)
+	normals := make([]float32, 0, count*3)
+	var uvs, colors []float32
+	if len(m.UVs) > 0 {
+		uvs = make([]float32, 0, count*2)
+	}
+	if len(m.Colors) > 0 {
+		colors = make([]float32, 0, count*4)
+	}
+
+	copyVertex := func(v uint32, n [3]float64) {
+		vertices = append(vertices, m.Vertices[v*3:v*3+3]...)
+		normals = append(normals, float32(n[0]), float32(n[1]), float32(n[2]))
+		if uvs != nil {
+			uvs = append(uvs, m.UVs[v*2:v*2+2]...)
+		}
+		if colors != nil {
+			colors = append(colors, m.Colors[v*4:v*4+4]...)
+		}
+	}
+	for t := 0; t < m.TriangleCount(); t++ {
+		p0, p1, p2 := m.triangle(t)
+		n := normalize(cross(sub(p1, p0), sub(p2, p0)))
+		for k := 0; k < 3; k++ {
+			copyVertex(m.Indices[t*3+k], n)
+		}
+	}
+
+	// Line and point vertices follow the triangle corners, with no normal.
+	moved := make(map[uint32]uint32)
+	remap := func(indices []uint32) {
+		for i, v := range indices {
+			idx, ok := moved[v]
+			if !ok {
+				idx = uint32(len(vertices) / 3)
+				moved[v] = idx
+				copyVertex(v, [3]float64{})
+			}
+			indices[i] = idx
+		}
+	}
+	remap(m.Lines)
+	remap(m.Points)
+
+	m.Vertices, m.Normals, m.UVs, m.Colors = vertices, normals, uvs, colors
+	m.Indices = make([]uint32, count)
+	for i := range m.Indices {
+		m.Indices[i] = uint32(i)
+	}
+}
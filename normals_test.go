@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestRecomputeNormals(t *testing.T) {
+	a := NewApp(defaultSettings())
+	cloud := a.meshes.add(&Mesh{Vertices: []float32{0, 0, 0, 1, 0, 0, 0, 1, 0}, PointCloud: true})
+	if err := a.RecomputeNormals(cloud.ID, false, true); err == nil {
+		t.Error("point cloud: want an error")
+	}
+	if cloud.VertexCount() != 3 {
+		t.Errorf("point cloud has %d vertices after a rejected call, want 3", cloud.VertexCount())
+	}
+
+	quad := a.meshes.add(&Mesh{
+		Vertices: []float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0},
+		Indices:  []uint32{0, 1, 2, 0, 2, 3},
+	})
+	if err := a.RecomputeNormals(quad.ID, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if quad.VertexCount() != 6 || len(quad.Normals) != 18 {
+		t.Fatalf("flat quad has %d vertices and %d normal values, want 6 and 18", quad.VertexCount(), len(quad.Normals))
+	}
+	for v := 0; v < 6; v++ {
+		if n := quad.Normals[v*3 : v*3+3]; n[0] != 0 || n[1] != 0 || n[2] != 1 {
+			t.Errorf("vertex %d normal = %v, want +Z", v, n)
+		}
+	}
+}
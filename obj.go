@@ -0,0 +1,515 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// objVertexKey identifies a unique position/uv/normal combination; -1 marks
+// an absent component.
+type objVertexKey struct {
+	v, vt, vn int
+}
+
+// objParser accumulates Wavefront OBJ state while scanning a file.
+type objParser struct {
+	dir string
+
+	positions []float32
+	uvs       []float32
+	normals   []float32
+	// badPositions marks v lines that failed to parse; a zero placeholder
+	// keeps later indices aligned and faces using it are skipped.
+	badPositions map[int]bool
+
+	keys    []objVertexKey
+	lookup  map[objVertexKey]uint32
+	indices []uint32
+	lines   []uint32
+	points  []uint32
+
+	materials []Material
+	hasUV     bool
+	hasNormal bool
+
+	// groups records where each run of faces with the current group name
+	// and material starts; material is resolved to an index once all
+	// libraries have been read.
+	groups        []objGroup
+	groupName     string
+	groupMaterial string
+
+	// meta collects the comment block at the top of the file; inBody is set
+	// at the first directive.
+	meta   ModelMeta
+	inBody bool
+}
+
+// objUnnamedGroup names faces that appear before any o or g statement.
+const objUnnamedGroup = "(unnamed)"
+
+type objGroup struct {
+	name, material string
+	start          int
+}
+
+// LoadOBJ parses a Wavefront OBJ file and the material libraries it references.
+func (a *App) LoadOBJ(path string) (*Mesh, error) {
+	mesh, err := loadFormat(context.Background(), formatForExtension(".obj"), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.modelLoaded(path, mesh), nil
+}
+
+func loadOBJ(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	f, _, err := openWithProgress(ctx, path, progress)
+	if err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+	defer f.Close()
+
+	return parseOBJ(f, filepath.Dir(path))
+}
+
+// parseOBJ reads OBJ data from r. dir is the directory used to resolve
+// mtllib and texture references.
+func parseOBJ(r io.Reader, dir string) (*Mesh, error) {
+	p := &objParser{
+		dir:       dir,
+		lookup:    make(map[objVertexKey]uint32),
+		groupName: objUnnamedGroup,
+	}
+
+	// A malformed line is skipped with a warning rather than failing the
+	// whole file, unless so many are bad that the file is likely not OBJ.
+	var issues parseIssues
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		if fields := strings.Fields(text); len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			issues.records++
+		}
+		if err := p.parseLine(text); err != nil {
+			var fatal fatalParseError
+			if errors.As(err, &fatal) {
+				return nil, fmt.Errorf("obj: line %d: %w", line, fatal.err)
+			}
+			issues.skip(fmt.Sprintf("line %d", line), err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+	if err := issues.err(); err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+
+	m := p.mesh()
+	issues.report(m)
+	return m, nil
+}
+
+func (p *objParser) parseLine(text string) error {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil
+	}
+	if strings.HasPrefix(fields[0], "#") {
+		if !p.inBody {
+			p.meta.addComment(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+		}
+		return nil
+	}
+	p.inBody = true
+
+	switch fields[0] {
+	case "v":
+		if err := appendOrPad(&p.positions, fields[1:], 3, 3); err != nil {
+			if p.badPositions == nil {
+				p.badPositions = make(map[int]bool)
+			}
+			p.badPositions[len(p.positions)/3-1] = true
+			return err
+		}
+	case "vt":
+		return appendOrPad(&p.uvs, fields[1:], 2, 1)
+	case "vn":
+		return appendOrPad(&p.normals, fields[1:], 3, 3)
+	case "f":
+		return p.parseFace(fields[1:])
+	case "l":
+		return p.parseLineElement(fields[1:])
+	case "p":
+		return p.parsePoints(fields[1:])
+	case "mtllib":
+		return p.parseMtllib(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "mtllib")))
+	case "o", "g":
+		// A bare g returns to the default group.
+		p.groupName = strings.Join(fields[1:], " ")
+		if p.groupName == "" {
+			p.groupName = objUnnamedGroup
+		}
+	case "usemtl":
+		p.groupMaterial = strings.Join(fields[1:], " ")
+	}
+	// Smoothing groups (s) and other directives do not affect the merged
+	// geometry.
+	return nil
+}
+
+// appendFloats parses n components of fields into dst, requiring at least
+// min of them and zero-filling the rest. Extra components (vertex weights,
+// 3D texture coordinates) are ignored.
+func appendFloats(dst *[]float32, fields []string, n, min int) error {
+	if len(fields) < min {
+		return fmt.Errorf("expected %d components, got %d", n, len(fields))
+	}
+	for i := 0; i < n; i++ {
+		if i >= len(fields) {
+			*dst = append(*dst, 0)
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", fields[i])
+		}
+		if !isFinite(v) {
+			return fmt.Errorf("non-finite number %q", fields[i])
+		}
+		*dst = append(*dst, float32(v))
+	}
+	return nil
+}
+
+// appendOrPad is appendFloats that appends n zeros instead when fields are
+// malformed, so the element keeps its place in the list.
+func appendOrPad(dst *[]float32, fields []string, n, min int) error {
+	start := len(*dst)
+	err := appendFloats(dst, fields, n, min)
+	if err != nil {
+		*dst = append((*dst)[:start], make([]float32, n)...)
+	}
+	return err
+}
+
+func (p *objParser) parseFace(fields []string) error {
+	if len(fields) < 3 {
+		return fmt.Errorf("face needs at least 3 vertices, got %d", len(fields))
+	}
+
+	corners, err := p.faceVertices(fields)
+	if err != nil {
+		return err
+	}
+
+	if n := len(p.groups); n == 0 || p.groups[n-1].name != p.groupName || p.groups[n-1].material != p.groupMaterial {
+		p.groups = append(p.groups, objGroup{name: p.groupName, material: p.groupMaterial, start: len(p.indices)})
+	}
+	// Fan triangulation handles triangles, quads and convex n-gons alike.
+	for i := 1; i+1 < len(corners); i++ {
+		p.indices = append(p.indices, corners[0], corners[i], corners[i+1])
+	}
+	return nil
+}
+
+// parseLineElement splits a polyline into segments.
+func (p *objParser) parseLineElement(fields []string) error {
+	if len(fields) < 2 {
+		return fmt.Errorf("line needs at least 2 vertices, got %d", len(fields))
+	}
+	corners, err := p.faceVertices(fields)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(corners); i++ {
+		p.lines = append(p.lines, corners[i-1], corners[i])
+	}
+	return nil
+}
+
+func (p *objParser) parsePoints(fields []string) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("point element without vertices")
+	}
+	corners, err := p.faceVertices(fields)
+	if err != nil {
+		return err
+	}
+	p.points = append(p.points, corners...)
+	return nil
+}
+
+// faceVertices resolves every reference of an element before creating any
+// vertex, so a line that fails part way leaves no unused vertices behind.
+func (p *objParser) faceVertices(fields []string) ([]uint32, error) {
+	keys := make([]objVertexKey, len(fields))
+	for i, field := range fields {
+		var err error
+		if keys[i], err = p.parseFaceVertex(field); err != nil {
+			return nil, err
+		}
+	}
+	corners := make([]uint32, len(keys))
+	for i, key := range keys {
+		corners[i] = p.vertex(key)
+	}
+	return corners, nil
+}
+
+// parseFaceVertex decodes one "v", "v/vt", "v//vn" or "v/vt/vn" reference.
+func (p *objParser) parseFaceVertex(field string) (objVertexKey, error) {
+	key := objVertexKey{-1, -1, -1}
+	parts := strings.Split(field, "/")
+	if len(parts) > 3 {
+		return key, fmt.Errorf("invalid face vertex %q", field)
+	}
+
+	var err error
+	if key.v, err = resolveOBJIndex(parts[0], len(p.positions)/3); err != nil {
+		return key, err
+	}
+	if p.badPositions[key.v] {
+		return key, fmt.Errorf("vertex %d is malformed", key.v+1)
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if key.vt, err = resolveOBJIndex(parts[1], len(p.uvs)/2); err != nil {
+			return key, err
+		}
+		p.hasUV = true
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if key.vn, err = resolveOBJIndex(parts[2], len(p.normals)/3); err != nil {
+			return key, err
+		}
+		p.hasNormal = true
+	}
+	return key, nil
+}
+
+// resolveOBJIndex converts a 1-based or negative (relative) OBJ index into a
+// 0-based index into a list that currently holds count elements.
+func resolveOBJIndex(s string, count int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	switch {
+	case n > 0:
+		n--
+	case n < 0:
+		n += count
+	default:
+		return 0, fmt.Errorf("index 0 is not valid")
+	}
+	if n < 0 || n >= count {
+		return 0, fmt.Errorf("index %s out of range (%d defined)", s, count)
+	}
+	return n, nil
+}
+
+func (p *objParser) vertex(key objVertexKey) uint32 {
+	if idx, ok := p.lookup[key]; ok {
+		return idx
+	}
+	idx := uint32(len(p.keys))
+	p.keys = append(p.keys, key)
+	p.lookup[key] = idx
+	return idx
+}
+
+// materialIndex returns the index of the named material, or -1.
+func (p *objParser) materialIndex(name string) int {
+	if name == "" {
+		return -1
+	}
+	for i, m := range p.materials {
+		if m.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseMtllib loads the named material libraries. Its errors are fatal: the
+// library is needed to show the model as authored, so it is not quietly
+// dropped like a malformed geometry line.
+func (p *objParser) parseMtllib(names string) error {
+	if names == "" {
+		return fatalParseError{fmt.Errorf("mtllib without file name")}
+	}
+	// Names are space separated; fall back to the whole string so libraries
+	// whose file name contains spaces still resolve.
+	candidates := strings.Fields(names)
+	if len(candidates) > 1 {
+		if _, err := os.Stat(resolveAssetPath(p.dir, names)); err == nil {
+			candidates = []string{names}
+		}
+	}
+	for _, name := range candidates {
+		path := resolveAssetPath(p.dir, name)
+		materials, err := loadMTL(path, p.dir)
+		if err != nil {
+			return fatalParseError{err}
+		}
+		p.materials = append(p.materials, materials...)
+	}
+	return nil
+}
+
+func (p *objParser) mesh() *Mesh {
+	m := &Mesh{
+		Vertices:  make([]float32, 0, len(p.keys)*3),
+		Indices:   p.indices,
+		Materials: p.materials,
+		Lines:     p.lines,
+		Points:    p.points,
+	}
+	if len(p.meta.Comments) > 0 {
+		m.Meta = &p.meta
+	}
+	for i, g := range p.groups {
+		end := len(p.indices)
+		if i+1 < len(p.groups) {
+			end = p.groups[i+1].start
+		}
+		m.Groups = append(m.Groups, SubMesh{
+			Name:     g.name,
+			Start:    g.start,
+			Count:    end - g.start,
+			Material: p.materialIndex(g.material),
+		})
+	}
+	if p.hasUV {
+		m.UVs = make([]float32, 0, len(p.keys)*2)
+	}
+	if p.hasNormal {
+		m.Normals = make([]float32, 0, len(p.keys)*3)
+	}
+
+	for _, key := range p.keys {
+		m.Vertices = append(m.Vertices, p.positions[key.v*3:key.v*3+3]...)
+		if p.hasUV {
+			if key.vt >= 0 {
+				m.UVs = append(m.UVs, p.uvs[key.vt*2:key.vt*2+2]...)
+			} else {
+				m.UVs = append(m.UVs, 0, 0)
+			}
+		}
+		if p.hasNormal {
+			if key.vn >= 0 {
+				m.Normals = append(m.Normals, p.normals[key.vn*3:key.vn*3+3]...)
+			} else {
+				m.Normals = append(m.Normals, 0, 0, 0)
+			}
+		}
+	}
+	return m
+}
+
+// loadMTL parses a material library. Texture paths are resolved against
+// texDir, the directory of the OBJ that referenced the library.
+func loadMTL(path, texDir string) ([]Material, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("mtllib %q not found", path)
+		}
+		return nil, fmt.Errorf("mtllib: %w", err)
+	}
+	defer f.Close()
+
+	var materials []Material
+	var cur *Material
+
+	sc := bufio.NewScanner(f)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if fields[0] == "newmtl" {
+			materials = append(materials, Material{
+				Name:         strings.Join(fields[1:], " "),
+				DiffuseColor: [3]float32{0.8, 0.8, 0.8},
+				Opacity:      1,
+			})
+			cur = &materials[len(materials)-1]
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		switch fields[0] {
+		case "Kd":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("%s:%d: Kd expects 3 components", filepath.Base(path), line)
+			}
+			for i := 0; i < 3; i++ {
+				v, err := strconv.ParseFloat(fields[i+1], 32)
+				if err != nil {
+					return nil, fmt.Errorf("%s:%d: invalid number %q", filepath.Base(path), line, fields[i+1])
+				}
+				cur.DiffuseColor[i] = float32(v)
+			}
+		case "d", "Tr":
+			if len(fields) < 2 {
+				continue
+			}
+			v, err := strconv.ParseFloat(fields[len(fields)-1], 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid number %q", filepath.Base(path), line, fields[len(fields)-1])
+			}
+			if fields[0] == "Tr" {
+				v = 1 - v
+			}
+			cur.Opacity = float32(v)
+		case "map_Kd", "map_Bump", "map_bump", "bump", "norm":
+			if len(fields) < 2 {
+				continue
+			}
+			// Texture options (-s, -o, -bm ...) precede the file name.
+			tex := resolveAssetPath(texDir, fields[len(fields)-1])
+			if _, err := os.Stat(tex); err != nil {
+				return nil, fmt.Errorf("material %q: texture %q not found", cur.Name, tex)
+			}
+			if fields[0] == "map_Kd" {
+				cur.DiffuseMap = tex
+			} else {
+				cur.BumpMap = tex
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("mtllib: %w", err)
+	}
+	return materials, nil
+}
+
+// resolveAssetPath resolves a reference found inside a model file. Exporters
+// on Windows often write backslash separators, so those are normalised first.
+func resolveAssetPath(dir, ref string) string {
+	ref = filepath.FromSlash(strings.ReplaceAll(ref, `\`, "/"))
+	if filepath.IsAbs(ref) {
+		return ref
+	}
+	abs, err := filepath.Abs(filepath.Join(dir, ref))
+	if err != nil {
+		return filepath.Join(dir, ref)
+	}
+	return abs
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseOBJMissingResourcesAreFatal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "tex.mtl"), []byte("newmtl a\nmap_Kd missing.png\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, obj, want string
+	}{
+		{"missing mtllib", "mtllib nope.mtl\nv 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n", `obj: line 1: mtllib`},
+		{"missing texture", "v 0 0 0\nv 1 0 0\nv 0 1 0\nmtllib tex.mtl\nf 1 2 3\n", `obj: line 4: material "a": texture`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOBJ(strings.NewReader(tt.obj), dir)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error = %v, want prefix %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOBJSkipsMalformedGeometry(t *testing.T) {
+	obj := "v 0 0 0\nv 1 0 0\nv 0 1 0\nv 1 1 0\nv x y z\nf 1 2 3\nf 2 4 3\n"
+	m, err := parseOBJ(strings.NewReader(obj), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.TriangleCount() != 2 || m.Meta == nil || m.Meta.SkippedRecords != 1 {
+		t.Errorf("triangles = %d, meta = %+v; want 2 triangles and one skipped record", m.TriangleCount(), m.Meta)
+	}
+}
+
+func TestParseOBJResolvesMaterials(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "textures"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"textures/brick.png": "png",
+		"lib.mtl": "newmtl red\nKd 1 0 0\nd 0.5\n" +
+			"newmtl brick\nmap_Kd -s 1 1 1 textures\\brick.png\nTr 0.25\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	obj := "mtllib lib.mtl\nv 0 0 0\nv 1 0 0\nv 0 1 0\nvt 0 0\nvt 1 0\nvt 0 1\n" +
+		"g left\nusemtl red\nf 1 2 3\n" +
+		"usemtl brick\nf 1/1 2/2 3/3\n" +
+		"g right\nusemtl ghost\nf 3 2 1\n"
+	m, err := parseOBJ(strings.NewReader(obj), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Materials) != 2 {
+		t.Fatalf("materials = %+v, want red and brick", m.Materials)
+	}
+	red, brick := m.Materials[0], m.Materials[1]
+	if red.Name != "red" || red.DiffuseColor != [3]float32{1, 0, 0} || red.Opacity != 0.5 || red.DiffuseMap != "" {
+		t.Errorf("red = %+v", red)
+	}
+	wantMap := filepath.Join(dir, "textures", "brick.png")
+	if brick.Name != "brick" || brick.DiffuseColor != [3]float32{0.8, 0.8, 0.8} || brick.Opacity != 0.75 || brick.DiffuseMap != wantMap {
+		t.Errorf("brick = %+v, want the default colour, opacity 0.75 and map %s", brick, wantMap)
+	}
+
+	want := []SubMesh{
+		{Name: "left", Start: 0, Count: 3, Material: 0},
+		{Name: "left", Start: 3, Count: 3, Material: 1},
+		{Name: "right", Start: 6, Count: 3, Material: -1},
+	}
+	if len(m.Groups) != len(want) {
+		t.Fatalf("groups = %+v, want %+v", m.Groups, want)
+	}
+	for i, g := range want {
+		if m.Groups[i] != g {
+			t.Errorf("group %d = %+v, want %+v", i, m.Groups[i], g)
+		}
+	}
+	if len(m.UVs) != m.VertexCount()*2 {
+		t.Errorf("%d UV values for %d vertices", len(m.UVs), m.VertexCount())
+	}
+}
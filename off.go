@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadOFF parses an Object File Format mesh, including the COFF (per-vertex
+// colour) and NOFF (per-vertex normal) variants.
+func (a *App) LoadOFF(path string) (*Mesh, error) {
+	mesh, err := loadFormat(context.Background(), formatForExtension(".off"), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.modelLoaded(path, mesh), nil
+}
+
+func loadOFF(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	f, _, err := openWithProgress(ctx, path, progress)
+	if err != nil {
+		return nil, fmt.Errorf("off: %w", err)
+	}
+	defer f.Close()
+
+	mesh, err := parseOFF(f)
+	if err != nil {
+		return nil, fmt.Errorf("off: %w", err)
+	}
+	return mesh, nil
+}
+
+// offLines yields non-blank lines with # comments removed, split into fields.
+// Comments before the first data line are kept as file metadata.
+type offLines struct {
+	sc     *bufio.Scanner
+	line   int
+	meta   ModelMeta
+	inBody bool
+}
+
+func (l *offLines) next() ([]string, error) {
+	for l.sc.Scan() {
+		l.line++
+		text := l.sc.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			if !l.inBody {
+				l.meta.addComment(text[i+1:])
+			}
+			text = text[:i]
+		}
+		if fields := strings.Fields(text); len(fields) > 0 {
+			l.inBody = true
+			return fields, nil
+		}
+	}
+	if err := l.sc.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func parseOFF(r io.Reader) (*Mesh, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	lines := &offLines{sc: sc}
+
+	fields, err := lines.next()
+	if err != nil {
+		return nil, fmt.Errorf("missing header")
+	}
+	// The keyword is OFF with optional ST, C and N prefixes, in that order.
+	keyword := fields[0]
+	if !strings.HasSuffix(keyword, "OFF") {
+		return nil, fmt.Errorf("line %d: expected OFF header, got %q", lines.line, keyword)
+	}
+	prefix := strings.TrimSuffix(keyword, "OFF")
+	hasUVs := strings.HasPrefix(prefix, "ST")
+	prefix = strings.TrimPrefix(prefix, "ST")
+	hasColors := strings.HasPrefix(prefix, "C")
+	prefix = strings.TrimPrefix(prefix, "C")
+	hasNormals := strings.HasPrefix(prefix, "N")
+	prefix = strings.TrimPrefix(prefix, "N")
+	if prefix != "" {
+		return nil, fmt.Errorf("line %d: unsupported OFF variant %q", lines.line, keyword)
+	}
+
+	// The counts may share the header line.
+	counts := fields[1:]
+	if len(counts) == 0 {
+		if counts, err = lines.next(); err != nil {
+			return nil, fmt.Errorf("missing vertex and face counts")
+		}
+	}
+	if len(counts) < 2 {
+		return nil, fmt.Errorf("line %d: expected vertex and face counts", lines.line)
+	}
+	numVertices, err1 := strconv.Atoi(counts[0])
+	numFaces, err2 := strconv.Atoi(counts[1])
+	if err1 != nil || err2 != nil || numVertices < 0 || numFaces < 0 {
+		return nil, fmt.Errorf("line %d: invalid counts %q", lines.line, strings.Join(counts, " "))
+	}
+
+	// Cap preallocation so a corrupt count cannot exhaust memory up front.
+	hint := min(numVertices, 1<<22)
+	m := &Mesh{Vertices: make([]float32, 0, hint*3)}
+	if len(lines.meta.Comments) > 0 {
+		m.Meta = &lines.meta
+	}
+	if hasNormals {
+		m.Normals = make([]float32, 0, hint*3)
+	}
+	if hasColors {
+		m.Colors = make([]float32, 0, hint*4)
+	}
+	if hasUVs {
+		m.UVs = make([]float32, 0, hint*2)
+	}
+
+	var extra []float32
+	for i := 0; i < numVertices; i++ {
+		fields, err := lines.next()
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d of %d: unexpected end of file", i, numVertices)
+		}
+		if err := appendFloats(&m.Vertices, fields, 3, 3); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lines.line, err)
+		}
+		rest := fields[3:]
+		if hasNormals {
+			if err := appendFloats(&m.Normals, rest, 3, 3); err != nil {
+				return nil, fmt.Errorf("line %d: normal: %w", lines.line, err)
+			}
+			rest = rest[3:]
+		}
+		if hasColors {
+			// Colours are RGB or RGBA, as 0-1 floats or 0-255 integers.
+			n := 4
+			if len(rest) == 3 || hasUVs && len(rest) == 5 {
+				n = 3
+			}
+			extra = extra[:0]
+			if err := appendFloats(&extra, rest, n, 3); err != nil {
+				return nil, fmt.Errorf("line %d: colour: %w", lines.line, err)
+			}
+			if n == 3 {
+				extra = append(extra, 1)
+				if isByteColor(extra[:3]) {
+					extra[3] = 255
+				}
+			}
+			if isByteColor(extra) {
+				for c := range extra {
+					extra[c] /= 255
+				}
+			}
+			m.Colors = append(m.Colors, extra...)
+			rest = rest[n:]
+		}
+		if hasUVs {
+			if err := appendFloats(&m.UVs, rest, 2, 2); err != nil {
+				return nil, fmt.Errorf("line %d: texture coordinate: %w", lines.line, err)
+			}
+		}
+	}
+
+	count := uint64(numVertices)
+	corners := make([]uint32, 0, 8)
+	for i := 0; i < numFaces; i++ {
+		fields, err := lines.next()
+		if err != nil {
+			return nil, fmt.Errorf("face %d of %d: unexpected end of file", i, numFaces)
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n < 0 || len(fields) < n+1 {
+			return nil, fmt.Errorf("line %d: invalid face", lines.line)
+		}
+		// Anything after the indices is a face colour, which is ignored.
+		corners = corners[:0]
+		for _, f := range fields[1 : n+1] {
+			v, err := strconv.ParseUint(f, 10, 32)
+			if err != nil || v >= count {
+				return nil, fmt.Errorf("line %d: vertex index %q out of range", lines.line, f)
+			}
+			corners = append(corners, uint32(v))
+		}
+		for k := 1; k+1 < len(corners); k++ {
+			m.Indices = append(m.Indices, corners[0], corners[k], corners[k+1])
+		}
+	}
+
+	if len(m.Indices) == 0 {
+		m.PointCloud = true
+	}
+	return m, nil
+}
+
+// isByteColor reports whether any channel exceeds 1, meaning the file uses
+// the 0-255 integer convention.
+func isByteColor(c []float32) bool {
+	for _, v := range c {
+		if v > 1 {
+			return true
+		}
+	}
+	return false
+}
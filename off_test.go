@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseOFF(t *testing.T) {
+	square := []float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0}
+	fan := []uint32{0, 1, 2, 0, 2, 3}
+	tests := []struct {
+		name    string
+		off     string
+		colors  []float32
+		normals []float32
+		err     string
+	}{
+		{
+			name: "OFF",
+			off:  "# made by hand\nOFF\n4 1 0\n0 0 0\n1 0 0\n1 1 0\n0 1 0\n4 0 1 2 3\n",
+		},
+		{
+			name:   "COFF with byte colours",
+			off:    "COFF 4 1 0\n0 0 0 255 0 0\n1 0 0 0 255 0 255\n1 1 0 0 0 255 0\n0 1 0 255 255 255\n4 0 1 2 3 1 0 0\n",
+			colors: []float32{1, 0, 0, 1, 0, 1, 0, 1, 0, 0, 1, 0, 1, 1, 1, 1},
+		},
+		{
+			name:   "COFF with float colours",
+			off:    "COFF\n4 1 0\n0 0 0 1 0 0 0.5\n1 0 0 0 1 0 0.5\n1 1 0 0 0 1 0.5\n0 1 0 1 1 1 0.5\n4 0 1 2 3\n",
+			colors: []float32{1, 0, 0, 0.5, 0, 1, 0, 0.5, 0, 0, 1, 0.5, 1, 1, 1, 0.5},
+		},
+		{
+			name:    "NOFF",
+			off:     "NOFF\n4 1 0\n0 0 0 0 0 1\n1 0 0 0 0 1\n1 1 0 0 0 1\n0 1 0 0 0 1\n4 0 1 2 3\n",
+			normals: []float32{0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1},
+		},
+		{name: "index out of range", off: "OFF\n4 1 0\n0 0 0\n1 0 0\n1 1 0\n0 1 0\n3 0 1 4\n", err: `line 7: vertex index "4" out of range`},
+		{name: "truncated", off: "OFF\n4 1 0\n0 0 0\n1 0 0\n", err: "vertex 2 of 4: unexpected end of file"},
+		{name: "unknown variant", off: "4OFF\n0 0 0\n", err: `unsupported OFF variant "4OFF"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := parseOFF(strings.NewReader(tt.off))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(m.Vertices, square) || !slices.Equal(m.Indices, fan) {
+				t.Errorf("vertices = %v, indices = %v", m.Vertices, m.Indices)
+			}
+			if !slices.Equal(m.Colors, tt.colors) {
+				t.Errorf("colours = %v, want %v", m.Colors, tt.colors)
+			}
+			if !slices.Equal(m.Normals, tt.normals) {
+				t.Errorf("normals = %v, want %v", m.Normals, tt.normals)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Peek summarises a model file without building its geometry.
+type Peek struct {
+	Format string     `json:"format"`
+	Min    [3]float64 `json:"min"`
+	Max    [3]float64 `json:"max"`
+	// VertexCount is the number of positions the file declares. Loading may
+	// give a different figure, since vertices are split where normals or UVs
+	// differ and STL corners are shared.
+	VertexCount int `json:"vertexCount"`
+	// TriangleCount counts faces as they would be triangulated on load.
+	TriangleCount int `json:"triangleCount"`
+}
+
+// PeekModel reports the bounding box and primitive counts of a model file.
+// OBJ, STL, PLY and OFF files are streamed once reading only positions and
+// face sizes, passing over malformed OBJ lines and PLY elements as loading
+// does; other formats are loaded in full and summarised. Nothing is
+// registered, so the result cannot be passed to mesh calls.
+func (a *App) PeekModel(path string) (*Peek, error) {
+	format, err := formatForPath(path)
+	if err != nil {
+		return nil, err
+	}
+	name := format.name
+	if isGzipFile(path) {
+		name += " (gzip)"
+	}
+
+	if format.peek == nil {
+		m, err := loadFormat(context.Background(), format, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		p := &Peek{VertexCount: m.VertexCount(), TriangleCount: m.TriangleCount()}
+		p.Min, p.Max = m.bounds()
+		p.Format = name
+		return p, nil
+	}
+
+	p, err := format.peek(path)
+	if err != nil {
+		appLog.error("model peek failed", "path", path, "format", format.name, "error", err)
+		return nil, err
+	}
+	p.Format = name
+	return p, nil
+}
+
+// peekBounds grows a bounding box one position at a time.
+type peekBounds struct {
+	p *Peek
+}
+
+func (b peekBounds) add(x, y, z float64) {
+	pt := [3]float64{x, y, z}
+	for i := range pt {
+		if b.p.VertexCount == 0 || pt[i] < b.p.Min[i] {
+			b.p.Min[i] = pt[i]
+		}
+		if b.p.VertexCount == 0 || pt[i] > b.p.Max[i] {
+			b.p.Max[i] = pt[i]
+		}
+	}
+	b.p.VertexCount++
+}
+
+// parseXYZ reads the first three fields as a position.
+func parseXYZ(fields []string) (x, y, z float64, err error) {
+	if len(fields) < 3 {
+		return 0, 0, 0, fmt.Errorf("expected 3 components, got %d", len(fields))
+	}
+	var v [3]float64
+	for i := range v {
+		if v[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid number %q", fields[i])
+		}
+		if !isFinite(v[i]) {
+			return 0, 0, 0, fmt.Errorf("non-finite number %q", fields[i])
+		}
+	}
+	return v[0], v[1], v[2], nil
+}
+
+// peekOBJ scans v lines for positions and counts the corners of f lines;
+// everything else, including materials, is skipped.
+func peekOBJ(path string) (*Peek, error) {
+	f, _, err := openWithProgress(context.Background(), path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+	defer f.Close()
+
+	p := &Peek{}
+	bounds := peekBounds{p}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			// Malformed positions are left out, as loading skips them.
+			if x, y, z, err := parseXYZ(fields[1:]); err == nil {
+				bounds.add(x, y, z)
+			}
+		case "f":
+			if n := len(fields) - 1; n >= 3 {
+				p.TriangleCount += n - 2
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+	return p, nil
+}
+
+// peekSTL reads only the corner coordinates of each facet. A gzipped file's
+// size is not known up front, so its encoding is judged from the header.
+func peekSTL(path string) (*Peek, error) {
+	f, size, err := openWithProgress(context.Background(), path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("stl: %w", err)
+	}
+	defer f.Close()
+	if isGzipFile(path) {
+		size = -1
+	}
+
+	br := bufio.NewReaderSize(f, 1<<16)
+	head, _ := br.Peek(512)
+	if len(head) == 0 {
+		return nil, fmt.Errorf("stl: file is empty")
+	}
+	p := &Peek{}
+	bounds := peekBounds{p}
+
+	if !isBinarySTL(head, size) {
+		sc := bufio.NewScanner(br)
+		line := 0
+		for sc.Scan() {
+			line++
+			fields := strings.Fields(sc.Text())
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "vertex":
+				x, y, z, err := parseXYZ(fields[1:])
+				if err != nil {
+					return nil, fmt.Errorf("stl: line %d: vertex: %w", line, err)
+				}
+				bounds.add(x, y, z)
+			case "endfacet":
+				p.TriangleCount++
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("stl: %w", err)
+		}
+		return p, nil
+	}
+
+	var header [stlHeaderSize + 4]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("stl: truncated header")
+	}
+	count := binary.LittleEndian.Uint32(header[stlHeaderSize:])
+	var tri [stlTriangleSize]byte
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(br, tri[:]); err != nil {
+			return nil, fmt.Errorf("stl: truncated at triangle %d of %d", i, count)
+		}
+		// Bytes 0-11 hold the facet normal and 48-49 the attribute word.
+		for v := 0; v < 3; v++ {
+			off := 12 + v*12
+			bounds.add(
+				float64(math.Float32frombits(binary.LittleEndian.Uint32(tri[off:]))),
+				float64(math.Float32frombits(binary.LittleEndian.Uint32(tri[off+4:]))),
+				float64(math.Float32frombits(binary.LittleEndian.Uint32(tri[off+8:]))),
+			)
+		}
+	}
+	p.TriangleCount = int(count)
+	return p, nil
+}
+
+// peekPLY reads the x, y and z properties of each vertex and the length of
+// each face list, stepping over every other value.
+func peekPLY(path string) (*Peek, error) {
+	f, _, err := openWithProgress(context.Background(), path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ply: %w", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReaderSize(f, 1<<16)
+	h, err := readPLYHeader(br)
+	if err != nil {
+		return nil, fmt.Errorf("ply: %w", err)
+	}
+	values := newPLYValueReader(h, br)
+	p := &Peek{}
+	bounds := peekBounds{p}
+	for _, el := range h.elements {
+		var err error
+		switch el.name {
+		case "vertex":
+			err = peekPLYVertices(values, el, bounds)
+		case "face":
+			err = peekPLYFaces(values, el, p)
+		default:
+			err = skipPLYElement(values, el)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ply: element %s: %w", el.name, err)
+		}
+	}
+	return p, nil
+}
+
+func peekPLYVertices(values plyValueReader, el plyElement, bounds peekBounds) error {
+	pos := [3]int{-1, -1, -1}
+	for i, prop := range el.properties {
+		if prop.list {
+			continue
+		}
+		switch prop.name {
+		case "x":
+			pos[0] = i
+		case "y":
+			pos[1] = i
+		case "z":
+			pos[2] = i
+		}
+	}
+	if pos[0] < 0 || pos[1] < 0 || pos[2] < 0 {
+		return fmt.Errorf("missing x/y/z properties")
+	}
+
+	row := make([]float64, len(el.properties))
+	for i := 0; i < el.count; i++ {
+		if err := values.next(); err != nil {
+			return fmt.Errorf("vertex %d: %w", i, err)
+		}
+		if err := readPLYRow(values, el, row); err != nil {
+			if !recoverable(err) {
+				return fmt.Errorf("vertex %d: %w", i, err)
+			}
+			continue
+		}
+		x, y, z := row[pos[0]], row[pos[1]], row[pos[2]]
+		if isFinite(x) && isFinite(y) && isFinite(z) {
+			bounds.add(x, y, z)
+		}
+	}
+	return nil
+}
+
+func peekPLYFaces(values plyValueReader, el plyElement, p *Peek) error {
+	for i := 0; i < el.count; i++ {
+		if err := values.next(); err != nil {
+			return fmt.Errorf("face %d: %w", i, err)
+		}
+		triangles, err := peekPLYFace(values, el)
+		if err != nil {
+			if !recoverable(err) {
+				return fmt.Errorf("face %d: %w", i, err)
+			}
+			continue
+		}
+		p.TriangleCount += triangles
+	}
+	return nil
+}
+
+// peekPLYFace reads one face instance and returns how many triangles its
+// vertex list makes.
+func peekPLYFace(values plyValueReader, el plyElement) (int, error) {
+	triangles := 0
+	for _, prop := range el.properties {
+		if !prop.list {
+			if _, err := values.read(prop.valueType); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		n, err := values.read(prop.countType)
+		if err != nil {
+			return 0, err
+		}
+		for k := 0; k < int(n); k++ {
+			if _, err := values.read(prop.valueType); err != nil {
+				return 0, err
+			}
+		}
+		if (prop.name == "vertex_indices" || prop.name == "vertex_index") && n >= 3 {
+			triangles = int(n) - 2
+		}
+	}
+	return triangles, nil
+}
+
+// peekOFF reads the position at the start of each vertex line and the
+// corner count at the start of each face line.
+func peekOFF(path string) (*Peek, error) {
+	f, _, err := openWithProgress(context.Background(), path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("off: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	lines := &offLines{sc: sc}
+	fields, err := lines.next()
+	if err != nil || !strings.HasSuffix(fields[0], "OFF") {
+		return nil, fmt.Errorf("off: missing OFF header")
+	}
+	counts := fields[1:]
+	if len(counts) == 0 {
+		if counts, err = lines.next(); err != nil {
+			return nil, fmt.Errorf("off: missing vertex and face counts")
+		}
+	}
+	if len(counts) < 2 {
+		return nil, fmt.Errorf("off: line %d: expected vertex and face counts", lines.line)
+	}
+	numVertices, err1 := strconv.Atoi(counts[0])
+	numFaces, err2 := strconv.Atoi(counts[1])
+	if err1 != nil || err2 != nil || numVertices < 0 || numFaces < 0 {
+		return nil, fmt.Errorf("off: line %d: invalid counts %q", lines.line, strings.Join(counts, " "))
+	}
+
+	p := &Peek{}
+	bounds := peekBounds{p}
+	for i := 0; i < numVertices; i++ {
+		fields, err := lines.next()
+		if err != nil {
+			return nil, fmt.Errorf("off: vertex %d of %d: unexpected end of file", i, numVertices)
+		}
+		x, y, z, err := parseXYZ(fields)
+		if err != nil {
+			return nil, fmt.Errorf("off: line %d: %w", lines.line, err)
+		}
+		bounds.add(x, y, z)
+	}
+	for i := 0; i < numFaces; i++ {
+		fields, err := lines.next()
+		if err != nil {
+			return nil, fmt.Errorf("off: face %d of %d: unexpected end of file", i, numFaces)
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("off: line %d: invalid face", lines.line)
+		}
+		if n >= 3 {
+			p.TriangleCount += n - 2
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPeekModelMatchesLoad(t *testing.T) {
+	const position = `{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}`
+	tests := []struct {
+		file      string
+		data      []byte
+		triangles int
+	}{
+		{"part.obj", []byte("v -1 0 0\nv 2 0 0\nv 0 3 -4\nv x y z\nf 1 2 3\n"), 1},
+		{"ascii.stl", asciiSTL(quadTriangles), 2},
+		{"binary.stl", binarySTL("solid but binary", -1, quadTriangles), 2},
+		{"quad.ply", plyQuad("binary_big_endian"), 2},
+		{"quad.off", []byte("OFF\n4 1 0\n0 0 0\n1 0 0\n1 1 0\n0 1 -5\n4 0 1 2 3\n"), 2},
+		// glTF has no streaming peek, so it is loaded and summarised.
+		{"tri.gltf", triangleGLTF(position, ""), 1},
+	}
+	a := NewApp(defaultSettings())
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			p, err := a.PeekModel(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := loadModel(context.Background(), path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lo, hi := m.bounds()
+			if p.Min != lo || p.Max != hi {
+				t.Errorf("peek bounds = %v to %v, load gives %v to %v", p.Min, p.Max, lo, hi)
+			}
+			if p.TriangleCount != tt.triangles || m.TriangleCount() != tt.triangles {
+				t.Errorf("peek counts %d triangles, load %d; want %d", p.TriangleCount, m.TriangleCount(), tt.triangles)
+			}
+			if p.Format != m.Meta.Format {
+				t.Errorf("peek format %q, load %q", p.Format, m.Meta.Format)
+			}
+		})
+	}
+}
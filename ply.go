@@ -0,0 +1,574 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type plyFormat int
+
+const (
+	plyASCII plyFormat = iota
+	plyBinaryLE
+	plyBinaryBE
+)
+
+// plyProperty is one declared property. For list properties countType holds
+// the type of the length prefix.
+type plyProperty struct {
+	name      string
+	valueType string
+	countType string
+	list      bool
+}
+
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+type plyHeader struct {
+	format   plyFormat
+	elements []plyElement
+	meta     ModelMeta
+	// lines is the number of lines up to and including end_header.
+	lines int
+}
+
+// plyValueReader yields successive numeric values from the body, in either
+// ASCII or binary encoding.
+type plyValueReader interface {
+	// next moves to the next element instance. ASCII bodies hold one per
+	// line, so a malformed instance can be skipped; binary ones have no
+	// delimiters and next does nothing.
+	next() error
+	read(valueType string) (float64, error)
+	// line is the line number of the current instance, or 0 for binary.
+	line() int
+}
+
+// LoadPLY parses an ASCII or binary PLY file, including optional normals,
+// texture coordinates and per-vertex colours.
+func (a *App) LoadPLY(path string) (*Mesh, error) {
+	mesh, err := loadFormat(context.Background(), formatForExtension(".ply"), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.modelLoaded(path, mesh), nil
+}
+
+func loadPLY(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	f, _, err := openWithProgress(ctx, path, progress)
+	if err != nil {
+		return nil, fmt.Errorf("ply: %w", err)
+	}
+	defer f.Close()
+
+	mesh, err := parsePLY(f)
+	if err != nil {
+		return nil, fmt.Errorf("ply: %w", err)
+	}
+	return mesh, nil
+}
+
+func parsePLY(r io.Reader) (*Mesh, error) {
+	br := bufio.NewReaderSize(r, 1<<16)
+	h, err := readPLYHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	values := newPLYValueReader(h, br)
+
+	// Malformed vertices and faces are skipped with a warning, up to a
+	// limit. A skipped vertex keeps a placeholder until the faces are read,
+	// so later indices still line up, and faces using it are dropped.
+	m := &Mesh{}
+	var issues parseIssues
+	var bad map[uint32]bool
+	for _, el := range h.elements {
+		var err error
+		switch el.name {
+		case "vertex":
+			bad, err = readPLYVertices(values, el, m, &issues)
+		case "face":
+			err = readPLYFaces(values, el, m, bad, &issues)
+		default:
+			err = skipPLYElement(values, el)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("element %s: %w", el.name, err)
+		}
+	}
+	if err := issues.err(); err != nil {
+		return nil, err
+	}
+	if len(bad) > 0 {
+		m.dropVertices(bad)
+	}
+
+	if len(m.Indices) == 0 {
+		m.PointCloud = true
+	}
+	if len(h.meta.Comments) > 0 {
+		m.Meta = &h.meta
+	}
+	issues.report(m)
+	return m, nil
+}
+
+func newPLYValueReader(h *plyHeader, br *bufio.Reader) plyValueReader {
+	switch h.format {
+	case plyBinaryLE:
+		return &plyBinaryReader{r: br, order: binary.LittleEndian}
+	case plyBinaryBE:
+		return &plyBinaryReader{r: br, order: binary.BigEndian}
+	}
+	sc := bufio.NewScanner(br)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &plyASCIIReader{sc: sc, lineNo: h.lines}
+}
+
+// plyLocation names element instance i for a warning.
+func plyLocation(values plyValueReader, element string, i int) string {
+	if line := values.line(); line > 0 {
+		return fmt.Sprintf("line %d (%s %d)", line, element, i)
+	}
+	return fmt.Sprintf("%s %d", element, i)
+}
+
+// recoverable reports whether a read error spoils only the current
+// instance. Running out of data ends the parse.
+func recoverable(err error) bool {
+	return !errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func readPLYHeader(r *bufio.Reader) (*plyHeader, error) {
+	magic, err := r.ReadString('\n')
+	if err != nil || strings.TrimSpace(magic) != "ply" {
+		return nil, fmt.Errorf("missing ply magic")
+	}
+
+	h := &plyHeader{lines: 1}
+	formatSeen := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("unterminated header")
+		}
+		h.lines++
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "format":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid format line")
+			}
+			switch fields[1] {
+			case "ascii":
+				h.format = plyASCII
+			case "binary_little_endian":
+				h.format = plyBinaryLE
+			case "binary_big_endian":
+				h.format = plyBinaryBE
+			default:
+				return nil, fmt.Errorf("unknown format %q", fields[1])
+			}
+			formatSeen = true
+		case "element":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid element line")
+			}
+			count, err := strconv.Atoi(fields[2])
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("invalid element count %q", fields[2])
+			}
+			h.elements = append(h.elements, plyElement{name: fields[1], count: count})
+		case "property":
+			if len(h.elements) == 0 {
+				return nil, fmt.Errorf("property before element")
+			}
+			prop, err := parsePLYProperty(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			el := &h.elements[len(h.elements)-1]
+			el.properties = append(el.properties, prop)
+		case "comment", "obj_info":
+			h.meta.addComment(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+		case "end_header":
+			if !formatSeen {
+				return nil, fmt.Errorf("header has no format line")
+			}
+			return h, nil
+		}
+	}
+}
+
+func parsePLYProperty(fields []string) (plyProperty, error) {
+	if len(fields) >= 4 && fields[0] == "list" {
+		p := plyProperty{list: true, countType: fields[1], valueType: fields[2], name: fields[3]}
+		if plyTypeSize(p.countType) == 0 || plyTypeSize(p.valueType) == 0 {
+			return p, fmt.Errorf("unknown list types %s %s", p.countType, p.valueType)
+		}
+		return p, nil
+	}
+	if len(fields) < 2 {
+		return plyProperty{}, fmt.Errorf("invalid property line")
+	}
+	p := plyProperty{valueType: fields[0], name: fields[1]}
+	if plyTypeSize(p.valueType) == 0 {
+		return p, fmt.Errorf("unknown property type %s", p.valueType)
+	}
+	return p, nil
+}
+
+// plyTypeSize returns the byte size of a PLY scalar type, or 0 if unknown.
+func plyTypeSize(t string) int {
+	switch t {
+	case "char", "int8", "uchar", "uint8":
+		return 1
+	case "short", "int16", "ushort", "uint16":
+		return 2
+	case "int", "int32", "uint", "uint32", "float", "float32":
+		return 4
+	case "double", "float64":
+		return 8
+	}
+	return 0
+}
+
+// plyColorScale maps integer colour channels to [0,1]; float channels are
+// assumed to be normalised already.
+func plyColorScale(t string) float64 {
+	switch t {
+	case "uchar", "uint8", "char", "int8":
+		return 1.0 / 255
+	case "ushort", "uint16", "short", "int16":
+		return 1.0 / 65535
+	}
+	return 1
+}
+
+// readPLYVertices appends the vertex element to m and returns the indices of
+// the vertices that were malformed, which hold zero placeholders.
+func readPLYVertices(values plyValueReader, el plyElement, m *Mesh, issues *parseIssues) (map[uint32]bool, error) {
+	slot := func(names ...string) int {
+		for i, p := range el.properties {
+			for _, n := range names {
+				if p.name == n && !p.list {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	pos := [3]int{slot("x"), slot("y"), slot("z")}
+	if pos[0] < 0 || pos[1] < 0 || pos[2] < 0 {
+		return nil, fmt.Errorf("missing x/y/z properties")
+	}
+	nrm := [3]int{slot("nx"), slot("ny"), slot("nz")}
+	uv := [2]int{slot("u", "s", "texture_u", "texture_s"), slot("v", "t", "texture_v", "texture_t")}
+	col := [4]int{slot("red", "diffuse_red", "r"), slot("green", "diffuse_green", "g"), slot("blue", "diffuse_blue", "b"), slot("alpha", "a")}
+
+	hasNormals := nrm[0] >= 0 && nrm[1] >= 0 && nrm[2] >= 0
+	hasUVs := uv[0] >= 0 && uv[1] >= 0
+	hasColors := col[0] >= 0 && col[1] >= 0 && col[2] >= 0
+
+	// Cap preallocation so a corrupt count cannot exhaust memory up front.
+	hint := min(el.count, 1<<22)
+	m.Vertices = make([]float32, 0, hint*3)
+	if hasNormals {
+		m.Normals = make([]float32, 0, hint*3)
+	}
+	if hasUVs {
+		m.UVs = make([]float32, 0, hint*2)
+	}
+	if hasColors {
+		m.Colors = make([]float32, 0, hint*4)
+	}
+
+	var bad map[uint32]bool
+	row := make([]float64, len(el.properties))
+	for i := 0; i < el.count; i++ {
+		if err := values.next(); err != nil {
+			return nil, fmt.Errorf("vertex %d: %w", i, err)
+		}
+		issues.records++
+		err := readPLYRow(values, el, row)
+		if err == nil && !(isFinite(row[pos[0]]) && isFinite(row[pos[1]]) && isFinite(row[pos[2]])) {
+			err = fmt.Errorf("non-finite position")
+		}
+		if err != nil {
+			if !recoverable(err) {
+				return nil, fmt.Errorf("vertex %d: %w", i, err)
+			}
+			issues.skip(plyLocation(values, "vertex", i), err)
+			if bad == nil {
+				bad = make(map[uint32]bool)
+			}
+			bad[uint32(m.VertexCount())] = true
+			clear(row)
+		}
+
+		m.Vertices = append(m.Vertices, float32(row[pos[0]]), float32(row[pos[1]]), float32(row[pos[2]]))
+		if hasNormals {
+			m.Normals = append(m.Normals, float32(row[nrm[0]]), float32(row[nrm[1]]), float32(row[nrm[2]]))
+		}
+		if hasUVs {
+			m.UVs = append(m.UVs, float32(row[uv[0]]), float32(row[uv[1]]))
+		}
+		if hasColors {
+			for c := 0; c < 4; c++ {
+				if col[c] < 0 {
+					m.Colors = append(m.Colors, 1)
+					continue
+				}
+				s := plyColorScale(el.properties[col[c]].valueType)
+				m.Colors = append(m.Colors, float32(row[col[c]]*s))
+			}
+		}
+	}
+	return bad, nil
+}
+
+// readPLYRow reads one instance's scalar properties into row, skipping
+// lists.
+func readPLYRow(values plyValueReader, el plyElement, row []float64) error {
+	for j, p := range el.properties {
+		if p.list {
+			if err := skipPLYList(values, p); err != nil {
+				return err
+			}
+			continue
+		}
+		v, err := values.read(p.valueType)
+		if err != nil {
+			return err
+		}
+		row[j] = v
+	}
+	return nil
+}
+
+func readPLYFaces(values plyValueReader, el plyElement, m *Mesh, bad map[uint32]bool, issues *parseIssues) error {
+	listIdx := -1
+	for i, p := range el.properties {
+		if p.list && (p.name == "vertex_indices" || p.name == "vertex_index") {
+			listIdx = i
+		}
+	}
+	if listIdx < 0 {
+		return fmt.Errorf("missing vertex_indices list")
+	}
+
+	var corners []uint32
+	for i := 0; i < el.count; i++ {
+		if err := values.next(); err != nil {
+			return fmt.Errorf("face %d: %w", i, err)
+		}
+		issues.records++
+		var err error
+		corners, err = readPLYFace(values, el, listIdx, uint32(m.VertexCount()), bad, corners[:0])
+		if err != nil {
+			if !recoverable(err) {
+				return fmt.Errorf("face %d: %w", i, err)
+			}
+			issues.skip(plyLocation(values, "face", i), err)
+			continue
+		}
+		for k := 1; k+1 < len(corners); k++ {
+			m.Indices = append(m.Indices, corners[0], corners[k], corners[k+1])
+		}
+	}
+	return nil
+}
+
+// readPLYFace reads one face instance in full and only then checks its
+// indices, so a binary reader stays aligned after a bad face.
+func readPLYFace(values plyValueReader, el plyElement, listIdx int, count uint32, bad map[uint32]bool, corners []uint32) ([]uint32, error) {
+	var invalid error
+	for j, p := range el.properties {
+		if j != listIdx {
+			if p.list {
+				if err := skipPLYList(values, p); err != nil {
+					return nil, err
+				}
+			} else if _, err := values.read(p.valueType); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		n, err := values.read(p.countType)
+		if err != nil {
+			return nil, err
+		}
+		for k := 0; k < int(n); k++ {
+			v, err := values.read(p.valueType)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case invalid != nil:
+			case v < 0 || uint32(v) >= count:
+				invalid = fmt.Errorf("vertex index %v out of range", v)
+			case bad[uint32(v)]:
+				invalid = fmt.Errorf("uses malformed vertex %v", v)
+			}
+			corners = append(corners, uint32(v))
+		}
+	}
+	return corners, invalid
+}
+
+// dropVertices removes the given vertices, which no triangle may use, and
+// renumbers the index buffer.
+func (m *Mesh) dropVertices(drop map[uint32]bool) {
+	hasNormals := len(m.Normals) == len(m.Vertices)
+	hasUVs := len(m.UVs) == m.VertexCount()*2
+	hasColors := len(m.Colors) == m.VertexCount()*4
+	remap := make([]uint32, m.VertexCount())
+	n := 0
+	for v := range remap {
+		if drop[uint32(v)] {
+			continue
+		}
+		remap[v] = uint32(n)
+		copy(m.Vertices[n*3:], m.Vertices[v*3:v*3+3])
+		if hasNormals {
+			copy(m.Normals[n*3:], m.Normals[v*3:v*3+3])
+		}
+		if hasUVs {
+			copy(m.UVs[n*2:], m.UVs[v*2:v*2+2])
+		}
+		if hasColors {
+			copy(m.Colors[n*4:], m.Colors[v*4:v*4+4])
+		}
+		n++
+	}
+	m.Vertices = m.Vertices[:n*3]
+	if hasNormals {
+		m.Normals = m.Normals[:n*3]
+	}
+	if hasUVs {
+		m.UVs = m.UVs[:n*2]
+	}
+	if hasColors {
+		m.Colors = m.Colors[:n*4]
+	}
+	for i, v := range m.Indices {
+		m.Indices[i] = remap[v]
+	}
+}
+
+// skipPLYElement reads past an element the viewer does not use. Malformed
+// ASCII values are ignored, since only the line structure matters.
+func skipPLYElement(values plyValueReader, el plyElement) error {
+	row := make([]float64, len(el.properties))
+	for i := 0; i < el.count; i++ {
+		if err := values.next(); err != nil {
+			return err
+		}
+		if err := readPLYRow(values, el, row); err != nil && !recoverable(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipPLYList(values plyValueReader, p plyProperty) error {
+	n, err := values.read(p.countType)
+	if err != nil {
+		return err
+	}
+	for k := 0; k < int(n); k++ {
+		if _, err := values.read(p.valueType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plyASCIIReader reads an ASCII body a line at a time.
+type plyASCIIReader struct {
+	sc     *bufio.Scanner
+	fields []string
+	lineNo int
+}
+
+func (r *plyASCIIReader) next() error {
+	r.fields = nil
+	for r.sc.Scan() {
+		r.lineNo++
+		if r.fields = strings.Fields(r.sc.Text()); len(r.fields) > 0 {
+			return nil
+		}
+	}
+	if err := r.sc.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+func (r *plyASCIIReader) read(string) (float64, error) {
+	if len(r.fields) == 0 {
+		return 0, fmt.Errorf("too few values")
+	}
+	text := r.fields[0]
+	r.fields = r.fields[1:]
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", text)
+	}
+	return v, nil
+}
+
+func (r *plyASCIIReader) line() int { return r.lineNo }
+
+type plyBinaryReader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	buf   [8]byte
+}
+
+func (r *plyBinaryReader) next() error { return nil }
+
+func (r *plyBinaryReader) line() int { return 0 }
+
+func (r *plyBinaryReader) read(t string) (float64, error) {
+	size := plyTypeSize(t)
+	b := r.buf[:size]
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+	switch t {
+	case "char", "int8":
+		return float64(int8(b[0])), nil
+	case "uchar", "uint8":
+		return float64(b[0]), nil
+	case "short", "int16":
+		return float64(int16(r.order.Uint16(b))), nil
+	case "ushort", "uint16":
+		return float64(r.order.Uint16(b)), nil
+	case "int", "int32":
+		return float64(int32(r.order.Uint32(b))), nil
+	case "uint", "uint32":
+		return float64(r.order.Uint32(b)), nil
+	case "float", "float32":
+		return float64(math.Float32frombits(r.order.Uint32(b))), nil
+	default:
+		return math.Float64frombits(r.order.Uint64(b)), nil
+	}
+}
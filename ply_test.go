@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// plyQuad encodes a unit quad with uchar vertex colours, and a face element
+// holding it as one four-sided face, in the given PLY format.
+func plyQuad(format string) []byte {
+	positions := [][3]float32{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}}
+	colours := [][3]uint8{{255, 0, 0}, {0, 255, 0}, {0, 0, 255}, {255, 255, 255}}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "ply\nformat %s 1.0\ncomment made by hand\n", format)
+	b.WriteString("element vertex 4\nproperty float x\nproperty float y\nproperty float z\n")
+	b.WriteString("property uchar red\nproperty uchar green\nproperty uchar blue\n")
+	b.WriteString("element face 1\nproperty list uchar int vertex_indices\nend_header\n")
+
+	var order binary.AppendByteOrder = binary.LittleEndian
+	switch format {
+	case "ascii":
+		for i, p := range positions {
+			c := colours[i]
+			fmt.Fprintf(&b, "%g %g %g %d %d %d\n", p[0], p[1], p[2], c[0], c[1], c[2])
+		}
+		b.WriteString("4 0 1 2 3\n")
+		return b.Bytes()
+	case "binary_big_endian":
+		order = binary.BigEndian
+	}
+	out := b.Bytes()
+	for i, p := range positions {
+		for _, v := range p {
+			out = order.AppendUint32(out, math.Float32bits(v))
+		}
+		out = append(out, colours[i][:]...)
+	}
+	out = append(out, 4)
+	for _, v := range []uint32{0, 1, 2, 3} {
+		out = order.AppendUint32(out, v)
+	}
+	return out
+}
+
+func TestParsePLY(t *testing.T) {
+	wantColors := []float32{1, 0, 0, 1, 0, 1, 0, 1, 0, 0, 1, 1, 1, 1, 1, 1}
+	for _, format := range []string{"ascii", "binary_little_endian", "binary_big_endian"} {
+		t.Run(format, func(t *testing.T) {
+			m, err := parsePLY(bytes.NewReader(plyQuad(format)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(m.Vertices, []float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0}) {
+				t.Errorf("vertices = %v", m.Vertices)
+			}
+			// The quad is fanned into two triangles.
+			if !slices.Equal(m.Indices, []uint32{0, 1, 2, 0, 2, 3}) {
+				t.Errorf("indices = %v", m.Indices)
+			}
+			if !slices.Equal(m.Colors, wantColors) {
+				t.Errorf("colours = %v, want %v", m.Colors, wantColors)
+			}
+			if m.PointCloud || m.Meta == nil || len(m.Meta.Comments) != 1 {
+				t.Errorf("point cloud %v, meta %+v", m.PointCloud, m.Meta)
+			}
+		})
+	}
+}
+
+func TestParsePLYPointCloud(t *testing.T) {
+	ply := "ply\nformat ascii 1.0\nelement vertex 2\nproperty float x\nproperty float y\nproperty float z\nend_header\n0 0 0\n1 2 3\n"
+	m, err := parsePLY(bytes.NewReader([]byte(ply)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.PointCloud || m.VertexCount() != 2 || len(m.Colors) != 0 {
+		t.Errorf("point cloud %v with %d vertices and %d colour values", m.PointCloud, m.VertexCount(), len(m.Colors))
+	}
+}
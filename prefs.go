@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Up axes a model may be authored with. The viewer itself is Y-up; many CAD
+// tools export Z-up.
+const (
+	upAxisY = "y"
+	upAxisZ = "z"
+)
+
+// ViewerPrefs is the look of the 3D view. Colours are "#rrggbb".
+type ViewerPrefs struct {
+	Background string `json:"background"`
+	Grid       bool   `json:"grid"`
+	GridColor  string `json:"gridColor"`
+	// UpAxis is "y" or "z" and decides which way camera fits and
+	// CenterModel treat as up.
+	UpAxis string `json:"upAxis"`
+}
+
+// defaultViewerPrefs keeps the window colour used before preferences
+// existed, with the frontend's grid.
+func defaultViewerPrefs() ViewerPrefs {
+	return ViewerPrefs{Background: "#1b2636", Grid: true, GridColor: "#77848b", UpAxis: upAxisY}
+}
+
+// GetViewerPrefs returns the saved viewer look, so the frontend can apply it
+// before the first frame.
+func (a *App) GetViewerPrefs() ViewerPrefs {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.settings.viewerPrefs()
+}
+
+// SetViewerPrefs validates and stores the viewer look and emits
+// prefs:changed with it so every open window updates.
+func (a *App) SetViewerPrefs(prefs ViewerPrefs) error {
+	var err error
+	for _, c := range []*string{&prefs.Background, &prefs.GridColor} {
+		if *c, err = normalizeColor(*c); err != nil {
+			return fmt.Errorf("viewer prefs: %w", err)
+		}
+	}
+	prefs.UpAxis = strings.ToLower(strings.TrimSpace(prefs.UpAxis))
+	if prefs.UpAxis != upAxisY && prefs.UpAxis != upAxisZ {
+		return fmt.Errorf("viewer prefs: unknown up axis %q (want y or z)", prefs.UpAxis)
+	}
+
+	a.mu.Lock()
+	a.settings.Viewer = &prefs
+	a.mu.Unlock()
+	if err := a.persistSettings(); err != nil {
+		return err
+	}
+	a.prefsChanged()
+	return nil
+}
+
+// prefsChanged emits prefs:changed with the current viewer look.
+func (a *App) prefsChanged() {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventPrefsChanged, a.GetViewerPrefs())
+	}
+}
+
+// viewerPrefs returns the stored viewer look, or the defaults when none has
+// been saved.
+func (s *Settings) viewerPrefs() ViewerPrefs {
+	if s.Viewer == nil {
+		return defaultViewerPrefs()
+	}
+	return *s.Viewer
+}
+
+// upAxis returns the up axis of the saved viewer look.
+func (a *App) upAxis() string {
+	return a.GetViewerPrefs().UpAxis
+}
+
+// viewBasis returns the three-quarter direction from a model towards the
+// camera, as thumbnails use it, and the camera's up vector for an up axis.
+func viewBasis(upAxis string) (dir, up [3]float64) {
+	if upAxis == upAxisZ {
+		// The Y-up direction turned a quarter about X.
+		d := thumbnailViewDir
+		return [3]float64{d[0], -d[2], d[1]}, [3]float64{0, 0, 1}
+	}
+	return thumbnailViewDir, [3]float64{0, 1, 0}
+}
+
+// upIndex returns the coordinate index of an up axis.
+func upIndex(upAxis string) int {
+	if upAxis == upAxisZ {
+		return 2
+	}
+	return 1
+}
+
+// normalizeColor accepts "#rgb" or "#rrggbb", with or without the hash, and
+// returns the lower-case "#rrggbb" form.
+func normalizeColor(s string) (string, error) {
+	hex := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return "", fmt.Errorf("invalid colour %q", s)
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return "", fmt.Errorf("invalid colour %q", s)
+	}
+	return "#" + hex, nil
+}
+
+// backgroundColour returns the background as the window's initial colour.
+func (p ViewerPrefs) backgroundColour() *options.RGBA {
+	v, err := strconv.ParseUint(strings.TrimPrefix(p.Background, "#"), 16, 32)
+	if err != nil {
+		p = defaultViewerPrefs()
+		v, _ = strconv.ParseUint(strings.TrimPrefix(p.Background, "#"), 16, 32)
+	}
+	return &options.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 1}
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+)
+
+func TestDefaultBackgroundMatchesWindowColour(t *testing.T) {
+	got := defaultSettings().viewerPrefs().backgroundColour()
+	if want := (options.RGBA{R: 27, G: 38, B: 54, A: 1}); *got != want {
+		t.Errorf("default background = %+v, want %+v", *got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// progressInterval caps progress events at roughly 20 per second.
+const progressInterval = 50 * time.Millisecond
+
+// progressFunc receives the amount of work done out of total. A nil
+// progressFunc is valid and discards updates.
+type progressFunc func(done, total int64)
+
+func (f progressFunc) report(done, total int64) {
+	if f != nil {
+		f(done, total)
+	}
+}
+
+// progressReader reports bytes consumed from r against total. Reads fail with
+// ctx's error once it is cancelled, which is how every loader notices a
+// cancelled load between chunks. A non-nil hash is fed every byte read.
+type progressReader struct {
+	ctx    context.Context
+	r      io.Reader
+	done   int64
+	total  int64
+	report progressFunc
+	hash   *contentHash
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := p.r.Read(b)
+	if p.hash != nil {
+		p.hash.write(b[:n])
+	}
+	p.done += int64(n)
+	p.report.report(p.done, p.total)
+	return n, err
+}
+
+// openWithProgress opens path for streaming, reporting bytes read.
+// Gzip-compressed files are inflated transparently; progress and the
+// returned size then refer to the compressed bytes on disk.
+func openWithProgress(ctx context.Context, path string, progress progressFunc) (io.ReadCloser, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	var r io.Reader = &progressReader{ctx: ctx, r: f, total: info.Size(), report: progress, hash: contentHashFor(ctx, path)}
+	if gzipped(f) {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			f.Close()
+			return nil, 0, corruptGzip(err)
+		}
+		r = zr
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, f}, info.Size(), nil
+}
+
+// readFileWithProgress is os.ReadFile with chunked progress reporting, for
+// formats that need random access to the whole file.
+func readFileWithProgress(ctx context.Context, path string, progress progressFunc) ([]byte, error) {
+	r, size, err := openWithProgress(ctx, path, progress)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data := make([]byte, 0, size)
+	buf := make([]byte, 1<<20)
+	for {
+		n, err := r.Read(buf)
+		data = append(data, buf[:n]...)
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// progressEmitter turns progress callbacks into throttled progress events
+// whose last argument is a 0-100 percentage.
+type progressEmitter struct {
+	ctx   context.Context
+	event string
+	args  []any
+
+	mu      sync.Mutex
+	last    time.Time
+	percent int
+}
+
+// newProgressEmitter reports as model:progress events carrying path.
+func newProgressEmitter(ctx context.Context, path string) *progressEmitter {
+	return &progressEmitter{ctx: ctx, event: eventModelProgress, args: []any{path}, percent: -1}
+}
+
+// newTaskProgressEmitter reports as load:progress events carrying the task
+// ID and path.
+func newTaskProgressEmitter(ctx context.Context, taskID, path string) *progressEmitter {
+	return &progressEmitter{ctx: ctx, event: eventLoadProgress, args: []any{taskID, path}, percent: -1}
+}
+
+func (e *progressEmitter) report(done, total int64) {
+	if total <= 0 {
+		return
+	}
+	percent := int(done * 100 / total)
+	percent = min(max(percent, 0), 100)
+
+	e.mu.Lock()
+	now := time.Now()
+	if percent == e.percent || (now.Sub(e.last) < progressInterval && percent < 100) {
+		e.mu.Unlock()
+		return
+	}
+	e.last, e.percent = now, percent
+	e.mu.Unlock()
+
+	args := append(append([]any(nil), e.args...), percent)
+	runtime.EventsEmit(e.ctx, e.event, args...)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const maxRecentFiles = 15
+
+// RecentFile is an entry of the recent-files list, most recent first.
+type RecentFile struct {
+	Path       string    `json:"path"`
+	Name       string    `json:"name"`
+	LastOpened time.Time `json:"lastOpened" ts_type:"string"`
+	Size       int64     `json:"size"`
+	// Hash is the content hash of the file when it was last opened.
+	Hash string `json:"hash,omitempty"`
+	// Missing is set when the file no longer exists, so the UI can grey it out.
+	Missing bool `json:"missing"`
+}
+
+// GetRecentFiles returns recently opened models with Missing refreshed.
+func (a *App) GetRecentFiles() []RecentFile {
+	a.mu.Lock()
+	files := append([]RecentFile(nil), a.settings.RecentFiles...)
+	a.mu.Unlock()
+
+	for i := range files {
+		_, err := os.Stat(files[i].Path)
+		files[i].Missing = err != nil
+	}
+	return files
+}
+
+// ClearRecentFiles empties the recent-files list.
+func (a *App) ClearRecentFiles() error {
+	a.mu.Lock()
+	a.settings.RecentFiles = nil
+	a.mu.Unlock()
+	a.refreshRecentMenu()
+	return a.persistSettings()
+}
+
+// modelLoaded registers a successfully loaded mesh and records its source in
+// the recent-files list. Any auto-reload watcher on another file is stopped.
+func (a *App) modelLoaded(path string, m *Mesh) *Mesh {
+	abs := a.addRecentFile(path, m.Meta.Hash)
+	a.stopWatchingOthers(abs)
+	a.warnIfHeavy(abs, m.TriangleCount())
+	a.assignUnits(m)
+	return a.meshes.addFile(abs, m)
+}
+
+// addRecentFile moves path to the front of the recent list and returns its
+// absolute path. Entries are deduplicated by absolute path only: copies of
+// a file are separate entries, and saved views and units follow content
+// across renames through the hash instead.
+func (a *App) addRecentFile(path, sum string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	entry := RecentFile{
+		Path:       abs,
+		Name:       filepath.Base(abs),
+		LastOpened: time.Now(),
+		Hash:       sum,
+	}
+	if info, err := os.Stat(abs); err == nil {
+		entry.Size = info.Size()
+	}
+
+	a.mu.Lock()
+	list := []RecentFile{entry}
+	for _, f := range a.settings.RecentFiles {
+		if f.Path != abs && len(list) < maxRecentFiles {
+			list = append(list, f)
+		}
+	}
+	a.settings.RecentFiles = list
+	a.mu.Unlock()
+	a.refreshRecentMenu()
+
+	if err := a.persistSettings(); err != nil {
+		appLog.error("saving recent files failed", "error", err)
+	}
+	return abs
+}
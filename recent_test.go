@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecentFilesKeepCopiesApart(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	data := []byte("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n")
+	original, cp := filepath.Join(dir, "original.obj"), filepath.Join(dir, "copy.obj")
+	for _, p := range []string{original, cp} {
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := NewApp(defaultSettings())
+	m, err := a.openModel(context.Background(), original, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SetModelUnits(m.ID, "in"); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{cp, original} {
+		if _, err := a.openModel(context.Background(), p, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := a.GetRecentFiles()
+	if len(files) != 2 || files[0].Path != original || files[1].Path != cp {
+		t.Fatalf("recent files = %+v, want original then copy", files)
+	}
+	if files[0].Hash != files[1].Hash {
+		t.Errorf("hashes %q and %q differ for identical content", files[0].Hash, files[1].Hash)
+	}
+
+	// Units chosen for the content still follow it to a new name.
+	moved := filepath.Join(dir, "moved.obj")
+	if err := os.Rename(original, moved); err != nil {
+		t.Fatal(err)
+	}
+	r, err := a.openModel(context.Background(), moved, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Units != "in" {
+		t.Errorf("moved file units = %q, want in", r.Units)
+	}
+	if n := len(a.GetRecentFiles()); n != 3 {
+		t.Errorf("%d recent files after the move, want 3", n)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrMeshNotFound is returned when a mesh ID is unknown.
+var ErrMeshNotFound = errors.New("mesh not found")
+
+// defaultCacheLimit bounds the total size of decoded meshes kept in memory.
+const defaultCacheLimit = 1 << 30
+
+// ModelInfo summarises a cached mesh for the loaded-models list.
+type ModelInfo struct {
+	ID string `json:"id"`
+	// Path is the source file, empty for meshes derived from another one
+	// such as simplified copies.
+	Path          string    `json:"path"`
+	Name          string    `json:"name"`
+	VertexCount   int       `json:"vertexCount"`
+	TriangleCount int       `json:"triangleCount"`
+	Bytes         int64     `json:"bytes"`
+	LastUsed      time.Time `json:"lastUsed" ts_type:"string"`
+}
+
+// meshRegistry keeps decoded meshes so later calls can refer to them by ID.
+// Entries are kept in least-recently-used order and the oldest are evicted
+// once their total size exceeds limit.
+type meshRegistry struct {
+	mu      sync.Mutex
+	next    int
+	entries map[string]*registryEntry
+	// byPath maps an absolute source path to the newest mesh loaded from it.
+	byPath map[string]string
+	// lru holds mesh IDs, most recently used at the front.
+	lru   *list.List
+	total int64
+	limit int64
+
+	// onEvict is called without the lock held for every evicted mesh.
+	onEvict func(id, path string)
+}
+
+type registryEntry struct {
+	mesh  *Mesh
+	path  string
+	stamp fileStamp
+	bytes int64
+	used  time.Time
+	elem  *list.Element
+}
+
+func newMeshRegistry(limit int64) *meshRegistry {
+	if limit <= 0 {
+		limit = defaultCacheLimit
+	}
+	return &meshRegistry{
+		entries: make(map[string]*registryEntry),
+		byPath:  make(map[string]string),
+		lru:     list.New(),
+		limit:   limit,
+	}
+}
+
+// meshBytes estimates the memory held by m's buffers.
+func meshBytes(m *Mesh) int64 {
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	n := len(m.Vertices) + len(m.Normals) + len(m.UVs) + len(m.Colors) + len(m.Indices) + len(m.Lines) + len(m.Points)
+	return int64(n)*4 + int64(len(m.Attributes))*2
+}
+
+// add assigns m a fresh ID and stores it.
+func (r *meshRegistry) add(m *Mesh) *Mesh {
+	return r.addFile("", m)
+}
+
+// addFile stores m like add and remembers path as its source, so a later
+// load of the same unchanged file can reuse it.
+func (r *meshRegistry) addFile(path string, m *Mesh) *Mesh {
+	r.mu.Lock()
+	r.next++
+	m.ID = fmt.Sprintf("mesh-%d", r.next)
+	e := &registryEntry{mesh: m, path: path, bytes: meshBytes(m), used: time.Now()}
+	if path != "" {
+		e.stamp, _ = statStamp(path)
+		r.byPath[path] = m.ID
+	}
+	e.elem = r.lru.PushFront(m.ID)
+	r.entries[m.ID] = e
+	r.total += e.bytes
+	evicted := r.evictLocked()
+	r.mu.Unlock()
+
+	r.notify(evicted)
+	return m
+}
+
+// replace swaps the mesh stored under id for m, which takes over the ID.
+func (r *meshRegistry) replace(id string, m *Mesh) error {
+	r.mu.Lock()
+	e, ok := r.entries[id]
+	if !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %q", ErrMeshNotFound, id)
+	}
+	m.ID = id
+	e.mesh = m
+	if e.path != "" {
+		e.stamp, _ = statStamp(e.path)
+	}
+	r.touchLocked(e)
+	evicted := r.evictLocked()
+	r.mu.Unlock()
+
+	r.notify(evicted)
+	return nil
+}
+
+// source returns the file a mesh was loaded from.
+func (r *meshRegistry) source(id string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrMeshNotFound, id)
+	}
+	if e.path == "" {
+		return "", fmt.Errorf("mesh %q has no source file", id)
+	}
+	return e.path, nil
+}
+
+func (r *meshRegistry) get(id string) (*Mesh, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMeshNotFound, id)
+	}
+	r.touchLocked(e)
+	return e.mesh, nil
+}
+
+// lookupPath returns the cached mesh for path if the file has not changed
+// since it was loaded.
+func (r *meshRegistry) lookupPath(path string) (*Mesh, bool) {
+	stamp, ok := statStamp(path)
+	if !ok {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[r.byPath[path]]
+	if !ok || e.stamp != stamp {
+		return nil, false
+	}
+	r.touchLocked(e)
+	return e.mesh, true
+}
+
+// remove drops a mesh from the cache.
+func (r *meshRegistry) remove(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[id]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrMeshNotFound, id)
+	}
+	r.deleteLocked(id, e)
+	return nil
+}
+
+// list returns the cached meshes, most recently used first.
+func (r *meshRegistry) list() []ModelInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ModelInfo, 0, len(r.entries))
+	for el := r.lru.Front(); el != nil; el = el.Next() {
+		e := r.entries[el.Value.(string)]
+		e.mesh.buffersMu.RLock()
+		info := ModelInfo{
+			ID:            e.mesh.ID,
+			Path:          e.path,
+			VertexCount:   e.mesh.VertexCount(),
+			TriangleCount: e.mesh.TriangleCount(),
+			Bytes:         e.bytes,
+			LastUsed:      e.used,
+		}
+		e.mesh.buffersMu.RUnlock()
+		if e.path != "" {
+			info.Name = filepath.Base(e.path)
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// setLimit changes the cache size limit, evicting immediately if needed.
+func (r *meshRegistry) setLimit(limit int64) {
+	r.mu.Lock()
+	r.limit = limit
+	evicted := r.evictLocked()
+	r.mu.Unlock()
+	r.notify(evicted)
+}
+
+// touchLocked marks e as just used. Its size is refreshed too, since
+// operations such as flat normal recomputation grow meshes in place.
+func (r *meshRegistry) touchLocked(e *registryEntry) {
+	e.used = time.Now()
+	r.lru.MoveToFront(e.elem)
+	bytes := meshBytes(e.mesh)
+	r.total += bytes - e.bytes
+	e.bytes = bytes
+}
+
+func (r *meshRegistry) deleteLocked(id string, e *registryEntry) {
+	r.lru.Remove(e.elem)
+	delete(r.entries, id)
+	if r.byPath[e.path] == id {
+		delete(r.byPath, e.path)
+	}
+	r.total -= e.bytes
+}
+
+// evictLocked drops least recently used meshes until the total fits the
+// limit. The most recent mesh is always kept, however large.
+func (r *meshRegistry) evictLocked() []registryEntry {
+	var evicted []registryEntry
+	for r.total > r.limit && r.lru.Len() > 1 {
+		id := r.lru.Back().Value.(string)
+		e := r.entries[id]
+		r.deleteLocked(id, e)
+		evicted = append(evicted, *e)
+	}
+	return evicted
+}
+
+func (r *meshRegistry) notify(evicted []registryEntry) {
+	if r.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		r.onEvict(e.mesh.ID, e.path)
+	}
+}
+
+// addScene registers every primitive mesh of s.
+func (r *meshRegistry) addScene(s *Scene) *Scene {
+	for i := range s.Meshes {
+		for _, p := range s.Meshes[i].Primitives {
+			r.add(p.Mesh)
+		}
+	}
+	return s
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// autoReloadPoll is how often a watched file is checked.
+	autoReloadPoll = 100 * time.Millisecond
+	// autoReloadSettle is how long size and modification time must stay the
+	// same before a change is picked up, so a file still being written by an
+	// exporter is not read half-finished.
+	autoReloadSettle = 300 * time.Millisecond
+)
+
+// fileWatcher polls one mesh's source file. Polling needs no platform support
+// and copes with editors that save by replacing the file.
+type fileWatcher struct {
+	meshID string
+	path   string
+	done   chan struct{}
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func statStamp(path string) (fileStamp, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, false
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}, true
+}
+
+// EnableAutoReload starts or stops watching the file meshID was loaded from.
+// When it changes the file is re-read and a model:reloaded event is emitted.
+// Only one file is watched at a time, and watching stops when another model
+// is loaded.
+func (a *App) EnableAutoReload(meshID string, enabled bool) error {
+	path, err := a.meshes.source(meshID)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.watcher != nil && (enabled || a.watcher.meshID == meshID) {
+		close(a.watcher.done)
+		a.watcher = nil
+	}
+	if enabled {
+		a.watcher = &fileWatcher{meshID: meshID, path: path, done: make(chan struct{})}
+		go a.watch(a.watcher)
+	}
+	return nil
+}
+
+// stopWatchingOthers stops the watcher unless it is already on path.
+func (a *App) stopWatchingOthers(path string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.watcher != nil && a.watcher.path != path {
+		close(a.watcher.done)
+		a.watcher = nil
+	}
+}
+
+// stopWatching stops the watcher if it is on meshID.
+func (a *App) stopWatching(meshID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.watcher != nil && a.watcher.meshID == meshID {
+		close(a.watcher.done)
+		a.watcher = nil
+	}
+}
+
+// watch reloads w's file once it has changed and then stayed unchanged for
+// autoReloadSettle. Further writes during that window restart it, which
+// debounces exporters that write in several passes.
+func (a *App) watch(w *fileWatcher) {
+	ticker := time.NewTicker(autoReloadPoll)
+	defer ticker.Stop()
+
+	last, _ := statStamp(w.path)
+	var changedAt time.Time
+	pending := false
+	for {
+		select {
+		case <-w.done:
+			return
+		case now := <-ticker.C:
+			// A missing file is usually mid-save; wait for it to reappear.
+			cur, ok := statStamp(w.path)
+			if !ok {
+				continue
+			}
+			if cur != last {
+				last, changedAt, pending = cur, now, true
+				continue
+			}
+			if pending && now.Sub(changedAt) >= autoReloadSettle {
+				pending = false
+				a.reload(w)
+			}
+		}
+	}
+}
+
+// reload re-reads w's file and swaps the result in under the same mesh ID.
+func (a *App) reload(w *fileWatcher) {
+	select {
+	case <-w.done:
+		return
+	default:
+	}
+	mesh, err := a.reloadMesh(w.meshID, w.path)
+	if err != nil {
+		runtime.EventsEmit(a.ctx, eventModelError, w.path, err.Error())
+		return
+	}
+	runtime.EventsEmit(a.ctx, eventModelReloaded, w.path, mesh.descriptor())
+}
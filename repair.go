@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RepairResult is a cleaned copy of a mesh and what was removed to make it.
+type RepairResult struct {
+	Mesh *Mesh `json:"mesh"`
+	// WeldedVertices is the number of vertices merged into a neighbour.
+	WeldedVertices      int `json:"weldedVertices"`
+	DegenerateTriangles int `json:"degenerateTriangles"`
+	DuplicateTriangles  int `json:"duplicateTriangles"`
+	OrphanVertices      int `json:"orphanVertices"`
+}
+
+// RepairMesh welds vertices closer than weldTolerance, drops degenerate and
+// duplicate triangles and removes vertices no triangle uses. The result is
+// registered as a new mesh; the original is not modified. A tolerance of
+// zero welds exact duplicates only.
+func (a *App) RepairMesh(meshID string, weldTolerance float64) (*RepairResult, error) {
+	if !(weldTolerance >= 0) {
+		return nil, fmt.Errorf("repair: invalid weld tolerance %v", weldTolerance)
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	if m.PointCloud || m.TriangleCount() == 0 {
+		m.buffersMu.RUnlock()
+		return nil, fmt.Errorf("repair: mesh %q has no triangles", meshID)
+	}
+	res := m.repair(weldTolerance)
+	m.buffersMu.RUnlock()
+	a.meshes.add(res.Mesh)
+	return res, nil
+}
+
+func (m *Mesh) repair(tol float64) *RepairResult {
+	res := &RepairResult{}
+	cluster := m.weldClusters(tol)
+	welded, reps := m.weldMap(cluster, true)
+	hasNormals := len(m.Normals) == len(m.Vertices)
+	hasUVs := len(m.UVs) == m.VertexCount()*2
+	hasColors := len(m.Colors) == m.VertexCount()*4
+	res.WeldedVertices = m.VertexCount() - len(reps)
+
+	min, max := m.bounds()
+	diag := length(sub(max, min))
+	out := m.derived()
+	groups := groupCursor{src: m.Groups}
+	remap := make(map[uint32]uint32)
+	seen := make(map[[3]uint32]bool)
+	for t := 0; t < m.TriangleCount(); t++ {
+		c := [3]uint32{cluster[m.Indices[t*3]], cluster[m.Indices[t*3+1]], cluster[m.Indices[t*3+2]]}
+		p0, p1, p2 := m.vertex(reps[welded[m.Indices[t*3]]]), m.vertex(reps[welded[m.Indices[t*3+1]]]), m.vertex(reps[welded[m.Indices[t*3+2]]])
+		if c[0] == c[1] || c[1] == c[2] || c[0] == c[2] ||
+			length(cross(sub(p1, p0), sub(p2, p0))) <= diag*diag*1e-12 {
+			res.DegenerateTriangles++
+			continue
+		}
+		// Triangles over the same corners are duplicates whatever their
+		// winding.
+		key := c
+		slices.Sort(key[:])
+		if seen[key] {
+			res.DuplicateTriangles++
+			continue
+		}
+		seen[key] = true
+
+		groups.add(out, t, len(out.Indices))
+		for k := 0; k < 3; k++ {
+			w := welded[m.Indices[t*3+k]]
+			idx, ok := remap[w]
+			if !ok {
+				idx = uint32(len(remap))
+				remap[w] = idx
+				src := reps[w]
+				out.Vertices = append(out.Vertices, m.Vertices[src*3:src*3+3]...)
+				if hasNormals {
+					out.Normals = append(out.Normals, m.Normals[src*3:src*3+3]...)
+				}
+				if hasUVs {
+					out.UVs = append(out.UVs, m.UVs[src*2:src*2+2]...)
+				}
+				if hasColors {
+					out.Colors = append(out.Colors, m.Colors[src*4:src*4+4]...)
+				}
+			}
+			out.Indices = append(out.Indices, idx)
+		}
+		if t < len(m.Attributes) {
+			out.Attributes = append(out.Attributes, m.Attributes[t])
+		}
+	}
+	res.OrphanVertices = len(reps) - len(remap)
+	res.Mesh = out
+	return res
+}
+
+// weldMap numbers the vertices that remain after welding by cluster. It
+// returns each vertex's new index and, per new index, the source vertex that
+// represents it. With matchAttributes, vertices only merge when their
+// normals, UVs and colours also agree, so seams stay intact.
+func (m *Mesh) weldMap(cluster []uint32, matchAttributes bool) (welded, reps []uint32) {
+	type vertexKey struct {
+		cluster uint32
+		normal  [3]float32
+		uv      [2]float32
+		color   [4]float32
+	}
+	hasNormals := matchAttributes && len(m.Normals) == len(m.Vertices)
+	hasUVs := matchAttributes && len(m.UVs) == m.VertexCount()*2
+	hasColors := matchAttributes && len(m.Colors) == m.VertexCount()*4
+	welded = make([]uint32, m.VertexCount())
+	firstOf := make(map[vertexKey]uint32)
+	for v := range welded {
+		k := vertexKey{cluster: cluster[v]}
+		if hasNormals {
+			copy(k.normal[:], m.Normals[v*3:])
+		}
+		if hasUVs {
+			copy(k.uv[:], m.UVs[v*2:])
+		}
+		if hasColors {
+			copy(k.color[:], m.Colors[v*4:])
+		}
+		id, ok := firstOf[k]
+		if !ok {
+			id = uint32(len(reps))
+			firstOf[k] = id
+			reps = append(reps, uint32(v))
+		}
+		welded[v] = id
+	}
+	return welded, reps
+}
+
+// weldClusters assigns every vertex the index of the first vertex within tol
+// of it, scanning in order. Candidates are found through a grid of tol-sized
+// cells; with tol zero only identical positions merge.
+func (m *Mesh) weldClusters(tol float64) []uint32 {
+	if tol == 0 {
+		return m.positionClasses()
+	}
+	cluster := make([]uint32, m.VertexCount())
+	grid := make(map[[3]int64][]uint32)
+	for v := range cluster {
+		p := m.vertex(uint32(v))
+		c := gridCell(p, tol)
+		cluster[v] = uint32(v)
+	search:
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for dz := int64(-1); dz <= 1; dz++ {
+					for _, r := range grid[[3]int64{c[0] + dx, c[1] + dy, c[2] + dz}] {
+						if length(sub(m.vertex(r), p)) <= tol {
+							cluster[v] = r
+							break search
+						}
+					}
+				}
+			}
+		}
+		if cluster[v] == uint32(v) {
+			grid[c] = append(grid[c], uint32(v))
+		}
+	}
+	return cluster
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxAspectRatio caps the aspect ratio of slivers so degenerate triangles
+// still get a finite value.
+const maxAspectRatio = 1000
+
+// ScalarField is a measurement sampled per vertex or per triangle for
+// heatmap display. Wails bindings return a single value, so the values and
+// legend range travel together.
+type ScalarField struct {
+	Field string `json:"field"`
+	// Domain is "vertex" when Values has one entry per vertex and "face"
+	// when it has one per triangle.
+	Domain string    `json:"domain"`
+	Values []float32 `json:"values"`
+	Range  Range     `json:"range"`
+}
+
+// Range is the closed interval covered by a scalar field.
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// ComputeScalarField evaluates field over a mesh. "area" and "aspectRatio"
+// are per triangle; "curvature" is a per-vertex mean curvature estimate.
+func (a *App) ComputeScalarField(meshID string, field string) (*ScalarField, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	f := &ScalarField{Field: field, Domain: "face"}
+	switch field {
+	case "area":
+		f.Values = m.triangleAreas()
+	case "aspectRatio":
+		f.Values = m.aspectRatios()
+	case "curvature":
+		f.Domain = "vertex"
+		f.Values = m.meanCurvature()
+	default:
+		return nil, fmt.Errorf("scalar field: unknown field %q (want area, aspectRatio or curvature)", field)
+	}
+	f.Range = valueRange(f.Values)
+	return f, nil
+}
+
+func valueRange(values []float32) Range {
+	if len(values) == 0 {
+		return Range{}
+	}
+	r := Range{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, v := range values {
+		r.Min = math.Min(r.Min, float64(v))
+		r.Max = math.Max(r.Max, float64(v))
+	}
+	return r
+}
+
+func (m *Mesh) triangleAreas() []float32 {
+	out := make([]float32, m.TriangleCount())
+	for t := range out {
+		p0, p1, p2 := m.triangle(t)
+		out[t] = float32(length(cross(sub(p1, p0), sub(p2, p0))) / 2)
+	}
+	return out
+}
+
+// aspectRatios returns circumradius over twice the inradius, which is 1 for
+// an equilateral triangle and grows without bound for slivers.
+func (m *Mesh) aspectRatios() []float32 {
+	out := make([]float32, m.TriangleCount())
+	for t := range out {
+		p0, p1, p2 := m.triangle(t)
+		a, b, c := length(sub(p1, p0)), length(sub(p2, p1)), length(sub(p0, p2))
+		area := length(cross(sub(p1, p0), sub(p2, p0))) / 2
+		ratio := float64(maxAspectRatio)
+		if area > 0 {
+			// R = abc/4A and r = A/s, so R/2r = abc·s / 8A².
+			s := (a + b + c) / 2
+			ratio = math.Min(a*b*c*s/(8*area*area), maxAspectRatio)
+		}
+		out[t] = float32(ratio)
+	}
+	return out
+}
+
+// meanCurvature estimates the mean curvature at each vertex from dihedral
+// angles: H ≈ Σ |e|·θe / 4A over the edges around the vertex, where θe is
+// the signed angle between the normals of the faces sharing e and A is a
+// third of the surrounding area. Convex regions are positive for outward
+// winding. Vertices sharing a position get the same value; boundary edges
+// contribute nothing.
+func (m *Mesh) meanCurvature() []float32 {
+	canon := m.positionClasses()
+	type edgeFaces struct {
+		faces [2]int
+		n     int
+	}
+	edges := make(map[uint64]*edgeFaces)
+	normals := make([][3]float64, m.TriangleCount())
+	area := make([]float64, m.VertexCount())
+	for t := range normals {
+		p0, p1, p2 := m.triangle(t)
+		c := cross(sub(p1, p0), sub(p2, p0))
+		normals[t] = normalize(c)
+		for k := 0; k < 3; k++ {
+			v := canon[m.Indices[t*3+k]]
+			area[v] += length(c) / 6
+			key := edgeKey(v, canon[m.Indices[t*3+(k+1)%3]])
+			e, ok := edges[key]
+			if !ok {
+				e = &edgeFaces{}
+				edges[key] = e
+			}
+			if e.n < 2 {
+				e.faces[e.n] = t
+			}
+			e.n++
+		}
+	}
+
+	sum := make([]float64, m.VertexCount())
+	for key, e := range edges {
+		// Only manifold interior edges have a well-defined dihedral angle.
+		if e.n != 2 {
+			continue
+		}
+		a, b := uint32(key>>32), uint32(key)
+		if a == b {
+			continue
+		}
+		dir := sub(m.vertex(b), m.vertex(a))
+		n1, n2 := normals[e.faces[0]], normals[e.faces[1]]
+		theta := math.Atan2(dot(cross(n1, n2), normalize(dir)), dot(n1, n2))
+		// The sign depends on which face traverses the edge as a -> b.
+		if !m.hasDirectedEdge(e.faces[0], a, b, canon) {
+			theta = -theta
+		}
+		w := length(dir) * theta / 2
+		sum[a] += w
+		sum[b] += w
+	}
+
+	out := make([]float32, m.VertexCount())
+	for v := range out {
+		c := canon[v]
+		if area[c] > 0 {
+			out[v] = float32(sum[c] / (2 * area[c]))
+		}
+	}
+	return out
+}
+
+// hasDirectedEdge reports whether triangle t runs from a to b.
+func (m *Mesh) hasDirectedEdge(t int, a, b uint32, canon []uint32) bool {
+	for k := 0; k < 3; k++ {
+		if canon[m.Indices[t*3+k]] == a && canon[m.Indices[t*3+(k+1)%3]] == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestComputeScalarField(t *testing.T) {
+	a := NewApp(defaultSettings())
+	cube := a.meshes.add(cubeMesh(2, [3]float32{}))
+	inverted := cubeMesh(2, [3]float32{})
+	for i := 0; i < len(inverted.Indices); i += 3 {
+		inverted.Indices[i+1], inverted.Indices[i+2] = inverted.Indices[i+2], inverted.Indices[i+1]
+	}
+	inverted = a.meshes.add(inverted)
+	grid := a.meshes.add(gridMesh(4))
+
+	// Every cube face splits into two right isosceles triangles of area 2.
+	isosceles := (2*math.Sqrt2 + 2) / 4
+	tests := []struct {
+		name     string
+		meshID   string
+		field    string
+		domain   string
+		min, max float64
+	}{
+		{"cube area", cube.ID, "area", "face", 2, 2},
+		{"cube aspect ratio", cube.ID, "aspectRatio", "face", isosceles, isosceles},
+		{"convex cube curvature", cube.ID, "curvature", "vertex", 0.1, math.Inf(1)},
+		{"inverted cube curvature", inverted.ID, "curvature", "vertex", math.Inf(-1), -0.1},
+		{"flat grid curvature", grid.ID, "curvature", "vertex", -1e-6, 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := a.ComputeScalarField(tt.meshID, tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Domain != tt.domain {
+				t.Errorf("Domain = %q, want %q", f.Domain, tt.domain)
+			}
+			m, _ := a.meshes.get(tt.meshID)
+			want := m.TriangleCount()
+			if tt.domain == "vertex" {
+				want = m.VertexCount()
+			}
+			if len(f.Values) != want {
+				t.Fatalf("%d values, want %d", len(f.Values), want)
+			}
+			for i, v := range f.Values {
+				if float64(v) < tt.min-1e-5 || float64(v) > tt.max+1e-5 {
+					t.Errorf("value %d = %g, want within [%g, %g]", i, v, tt.min, tt.max)
+				}
+			}
+			if f.Range.Min != float64(slices.Min(f.Values)) || f.Range.Max != float64(slices.Max(f.Values)) {
+				t.Errorf("Range = %+v, does not match values", f.Range)
+			}
+		})
+	}
+
+	if _, err := a.ComputeScalarField(cube.ID, "roughness"); err == nil {
+		t.Error("unknown field: want error")
+	}
+}
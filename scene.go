@@ -0,0 +1,194 @@
+package main
+
+import "cmp"
+
+// Scene is a node hierarchy referencing decoded meshes, as produced by the
+// glTF and 3MF loaders.
+type Scene struct {
+	Nodes     []SceneNode    `json:"nodes"`
+	Roots     []int          `json:"roots"`
+	Meshes    []SceneMesh    `json:"meshes"`
+	Materials []PBRMaterial  `json:"materials"`
+	Textures  []SceneTexture `json:"textures"`
+	Images    []SceneImage   `json:"images"`
+	Meta      *ModelMeta     `json:"meta,omitempty"`
+	// Bounds and Errors are only set by LoadMultiple.
+	Bounds *Bounds     `json:"bounds,omitempty"`
+	Errors []FileError `json:"errors,omitempty"`
+}
+
+// Bounds is an axis-aligned bounding box.
+type Bounds struct {
+	Min [3]float64 `json:"min"`
+	Max [3]float64 `json:"max"`
+}
+
+// FileError reports a file that could not be loaded.
+type FileError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// SceneNode is one entry of the node tree. Mesh is an index into
+// Scene.Meshes or -1. When Matrix is set it replaces the TRS components.
+type SceneNode struct {
+	Name        string       `json:"name"`
+	Children    []int        `json:"children"`
+	Mesh        int          `json:"mesh"`
+	Translation [3]float64   `json:"translation"`
+	Rotation    [4]float64   `json:"rotation"`
+	Scale       [3]float64   `json:"scale"`
+	Matrix      *[16]float64 `json:"matrix,omitempty"`
+}
+
+// SceneMesh groups the primitives drawn for a node.
+type SceneMesh struct {
+	Name       string      `json:"name"`
+	Primitives []Primitive `json:"primitives"`
+}
+
+// Primitive is a single draw call. Material is an index into
+// Scene.Materials or -1. Mode follows the glTF topology enum; strips and fans
+// are converted to plain triangle lists (mode 4).
+type Primitive struct {
+	Mesh     *Mesh `json:"mesh"`
+	Material int   `json:"material"`
+	Mode     int   `json:"mode"`
+}
+
+// PBRMaterial carries glTF metallic-roughness parameters. Texture fields are
+// indices into Scene.Textures or -1.
+type PBRMaterial struct {
+	Name                     string     `json:"name"`
+	BaseColorFactor          [4]float64 `json:"baseColorFactor"`
+	MetallicFactor           float64    `json:"metallicFactor"`
+	RoughnessFactor          float64    `json:"roughnessFactor"`
+	EmissiveFactor           [3]float64 `json:"emissiveFactor"`
+	BaseColorTexture         int        `json:"baseColorTexture"`
+	MetallicRoughnessTexture int        `json:"metallicRoughnessTexture"`
+	NormalTexture            int        `json:"normalTexture"`
+	OcclusionTexture         int        `json:"occlusionTexture"`
+	EmissiveTexture          int        `json:"emissiveTexture"`
+	AlphaMode                string     `json:"alphaMode"`
+	DoubleSided              bool       `json:"doubleSided"`
+}
+
+// SceneTexture references an entry of Scene.Images or -1.
+type SceneTexture struct {
+	Image int `json:"image"`
+}
+
+// SceneImage is either an external file (Path, absolute) or embedded bytes.
+type SceneImage struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Path     string `json:"path,omitempty"`
+	Data     []byte `json:"data,omitempty"`
+}
+
+// localMatrix returns the node's transform relative to its parent.
+func (n *SceneNode) localMatrix() mat4 {
+	if n.Matrix != nil {
+		return mat4(*n.Matrix)
+	}
+	return trsMat4(n.Translation, n.Rotation, n.Scale)
+}
+
+// flatten bakes node transforms into a single triangle mesh, for callers that
+// only need merged geometry. Each primitive becomes a group carrying its
+// material. Non-triangle primitives are skipped.
+func (s *Scene) flatten() *Mesh {
+	out := &Mesh{}
+	for _, m := range s.Materials {
+		out.Materials = append(out.Materials, s.simpleMaterial(m))
+	}
+
+	var visit func(idx int, parent mat4, depth int)
+	visit = func(idx int, parent mat4, depth int) {
+		// Guard against malformed documents with cyclic node references.
+		if depth > len(s.Nodes) {
+			return
+		}
+		node := &s.Nodes[idx]
+		world := parent.mul(node.localMatrix())
+		if node.Mesh >= 0 {
+			sm := s.Meshes[node.Mesh]
+			for _, prim := range sm.Primitives {
+				if prim.Mode != gltfTriangles {
+					continue
+				}
+				start := len(out.Indices)
+				out.appendTransformed(prim.Mesh, world)
+				if n := len(out.Indices) - start; n > 0 {
+					out.Groups = append(out.Groups, SubMesh{Name: cmp.Or(sm.Name, node.Name), Start: start, Count: n, Material: prim.Material})
+				}
+			}
+		}
+		for _, c := range node.Children {
+			visit(c, world, depth+1)
+		}
+	}
+	for _, root := range s.Roots {
+		visit(root, identityMat4(), 0)
+	}
+	return out
+}
+
+func (s *Scene) simpleMaterial(m PBRMaterial) Material {
+	metallic, roughness := float32(m.MetallicFactor), float32(m.RoughnessFactor)
+	mat := Material{
+		Name:         m.Name,
+		DiffuseColor: [3]float32{float32(m.BaseColorFactor[0]), float32(m.BaseColorFactor[1]), float32(m.BaseColorFactor[2])},
+		Opacity:      float32(m.BaseColorFactor[3]),
+		Metallic:     &metallic,
+		Roughness:    &roughness,
+	}
+	if t := m.BaseColorTexture; t >= 0 && t < len(s.Textures) {
+		if img := s.Textures[t].Image; img >= 0 && img < len(s.Images) {
+			mat.DiffuseMap = s.Images[img].Path
+		}
+	}
+	return mat
+}
+
+// appendTransformed merges src into m with positions and normals transformed
+// by world. Attributes missing on either side are zero-filled so the buffers
+// stay aligned.
+func (m *Mesh) appendTransformed(src *Mesh, world mat4) {
+	base := uint32(m.VertexCount())
+	count := src.VertexCount()
+	normalMat, invertible := world.normalMatrix()
+
+	withNormals := len(m.Normals) > 0 || len(src.Normals) > 0
+	withUVs := len(m.UVs) > 0 || len(src.UVs) > 0
+	if withNormals && len(m.Normals) == 0 {
+		m.Normals = make([]float32, int(base)*3, (int(base)+count)*3)
+	}
+	if withUVs && len(m.UVs) == 0 {
+		m.UVs = make([]float32, int(base)*2, (int(base)+count)*2)
+	}
+
+	for i := 0; i < count; i++ {
+		p := world.transformPoint([3]float64{float64(src.Vertices[i*3]), float64(src.Vertices[i*3+1]), float64(src.Vertices[i*3+2])})
+		m.Vertices = append(m.Vertices, float32(p[0]), float32(p[1]), float32(p[2]))
+
+		if withNormals {
+			if len(src.Normals) > 0 && invertible {
+				n := transformNormal(normalMat, [3]float64{float64(src.Normals[i*3]), float64(src.Normals[i*3+1]), float64(src.Normals[i*3+2])})
+				m.Normals = append(m.Normals, float32(n[0]), float32(n[1]), float32(n[2]))
+			} else {
+				m.Normals = append(m.Normals, 0, 0, 0)
+			}
+		}
+		if withUVs {
+			if len(src.UVs) > 0 {
+				m.UVs = append(m.UVs, src.UVs[i*2], src.UVs[i*2+1])
+			} else {
+				m.UVs = append(m.UVs, 0, 0)
+			}
+		}
+	}
+	for _, idx := range src.Indices {
+		m.Indices = append(m.Indices, base+idx)
+	}
+}
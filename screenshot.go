@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	pngSignature = "\x89PNG\r\n\x1a\n"
+	// maxScreenshotBytes bounds the payload accepted from the frontend; an
+	// 8K canvas compresses well below this.
+	maxScreenshotBytes = 64 << 20
+)
+
+// SaveScreenshot asks where to save a PNG rendered by the frontend canvas,
+// writes it, and returns the chosen path. The dialog opens in the directory
+// of the previous screenshot. As with any []byte argument, the frontend must
+// pass pngData base64-encoded, e.g. the part of a data URL after the comma.
+func (a *App) SaveScreenshot(pngData []byte, suggestedName string) (string, error) {
+	if len(pngData) > maxScreenshotBytes {
+		return "", fmt.Errorf("screenshot: image is %d bytes, more than the %d byte limit", len(pngData), maxScreenshotBytes)
+	}
+	if !bytes.HasPrefix(pngData, []byte(pngSignature)) {
+		return "", fmt.Errorf("screenshot: data is not a PNG image")
+	}
+
+	name := filepath.Base(strings.TrimSpace(suggestedName))
+	if name == "." || name == string(filepath.Separator) {
+		name = "screenshot"
+	}
+	if !strings.EqualFold(filepath.Ext(name), ".png") {
+		name += ".png"
+	}
+
+	a.mu.Lock()
+	dir := a.settings.LastSaveDir
+	if dir == "" {
+		dir = a.lastDir
+	}
+	a.mu.Unlock()
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            "Save Screenshot",
+		DefaultDirectory: dir,
+		DefaultFilename:  name,
+		Filters:          []runtime.FileFilter{{DisplayName: "PNG Image (*.png)", Pattern: "*.png"}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", ErrDialogCancelled
+	}
+	if filepath.Ext(path) == "" {
+		path += ".png"
+	}
+
+	err = writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(pngData)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("screenshot: %w", err)
+	}
+
+	a.mu.Lock()
+	a.settings.LastSaveDir = filepath.Dir(path)
+	a.mu.Unlock()
+	if err := a.persistSettings(); err != nil {
+		appLog.error("saving screenshot directory failed", "error", err)
+	}
+	return path, nil
+}
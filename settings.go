@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const appConfigDirName = "Simple3DViewer"
+
+// Settings is the persisted per-user configuration.
+type Settings struct {
+	Window      WindowState  `json:"window"`
+	RecentFiles []RecentFile `json:"recentFiles"`
+	// DefaultUnits is assigned to models whose format has no unit.
+	DefaultUnits string `json:"defaultUnits,omitempty"`
+	// MaxCacheBytes limits the memory used by cached meshes; zero selects
+	// defaultCacheLimit.
+	MaxCacheBytes int64 `json:"maxCacheBytes,omitempty"`
+	// HeavyThreshold is the triangle count above which model:heavy is
+	// emitted; zero selects defaultHeavyThreshold.
+	HeavyThreshold int `json:"heavyThreshold,omitempty"`
+	// LastSaveDir is where the previous screenshot was saved.
+	LastSaveDir string `json:"lastSaveDir,omitempty"`
+	// Views holds saved camera views by model key; see modelKey.
+	Views map[string][]NamedView `json:"views,omitempty"`
+	// Viewer is the background, grid and up-axis look; nil selects
+	// defaultViewerPrefs.
+	Viewer *ViewerPrefs `json:"viewer,omitempty"`
+	// ModelUnits holds the unit chosen for individual models by model key.
+	ModelUnits map[string]string `json:"modelUnits,omitempty"`
+}
+
+// WindowState is the window geometry saved on close. A zero Width means no
+// geometry has been saved yet.
+type WindowState struct {
+	Width     int  `json:"width"`
+	Height    int  `json:"height"`
+	X         int  `json:"x"`
+	Y         int  `json:"y"`
+	Maximized bool `json:"maximized"`
+}
+
+func defaultSettings() *Settings {
+	return &Settings{}
+}
+
+// configDir returns the application's directory under os.UserConfigDir.
+func configDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appConfigDirName), nil
+}
+
+func settingsPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "settings.json"), nil
+}
+
+// loadSettings reads the settings file, returning defaults when it is missing
+// or unreadable so a bad file never prevents startup.
+func loadSettings() *Settings {
+	s := defaultSettings()
+	path, err := settingsPath()
+	if err != nil {
+		return s
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		appLog.warn("ignoring invalid settings file", "path", path, "error", err)
+		return defaultSettings()
+	}
+	return s
+}
+
+func saveSettings(s *Settings) error {
+	path, err := settingsPath()
+	if err != nil {
+		return fmt.Errorf("settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("settings: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("settings: %w", err)
+	}
+	err = writeFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("settings: %w", err)
+	}
+	return nil
+}
+
+// ResetSettings restores every preference to its default and saves the
+// result, keeping the recent-files list when keepRecentFiles is set. Saved
+// camera views and per-model units are data about models rather than
+// preferences, so they are always kept. The unit, cache and viewer preferences
+// apply at once; the default window geometry applies from the next launch,
+// and the current geometry is no longer saved on close.
+func (a *App) ResetSettings(keepRecentFiles bool) error {
+	fresh := defaultSettings()
+	a.mu.Lock()
+	if keepRecentFiles {
+		fresh.RecentFiles = a.settings.RecentFiles
+	}
+	fresh.Views = a.settings.Views
+	fresh.ModelUnits = a.settings.ModelUnits
+	*a.settings = *fresh
+	a.windowReset = true
+	a.mu.Unlock()
+
+	a.meshes.setLimit(defaultCacheLimit)
+	a.refreshRecentMenu()
+	a.prefsChanged()
+	appLog.info("settings reset", "keepRecentFiles", keepRecentFiles)
+	return a.persistSettings()
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestResetSettingsKeepsModelData(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("HOME", home)
+
+	for _, keep := range []bool{false, true} {
+		s := defaultSettings()
+		s.RecentFiles = []RecentFile{{Path: "/models/part.stl"}}
+		s.DefaultUnits = "in"
+		s.Views = map[string][]NamedView{"part": {{Name: "top"}}}
+		s.ModelUnits = map[string]string{"part": "cm"}
+		a := NewApp(s)
+
+		if err := a.ResetSettings(keep); err != nil {
+			t.Fatal(err)
+		}
+		if a.settings.DefaultUnits != defaultSettings().DefaultUnits {
+			t.Errorf("keep %v: default units = %q, want the default", keep, a.settings.DefaultUnits)
+		}
+		if len(a.settings.Views["part"]) != 1 || a.settings.ModelUnits["part"] != "cm" {
+			t.Errorf("keep %v: views %v, model units %v; want them kept", keep, a.settings.Views, a.settings.ModelUnits)
+		}
+		if got := len(a.settings.RecentFiles) == 1; got != keep {
+			t.Errorf("keep %v: recent files = %v", keep, a.settings.RecentFiles)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+const (
+	// simplifyBoundaryWeight scales the quadrics that pin open edges in
+	// place, so the silhouette survives well after the interior has been
+	// reduced.
+	simplifyBoundaryWeight = 1000
+	// simplifyMinFaceDot rejects collapses that swing a neighbouring face's
+	// normal by more than roughly 78 degrees, which would fold the surface.
+	simplifyMinFaceDot = 0.2
+)
+
+// Simplify decimates a loaded mesh to roughly targetRatio of its triangles
+// using quadric error metric edge collapse. The result is registered as a new
+// mesh and the original is left untouched. Texture coordinates and per-face
+// attributes do not survive the collapse and are dropped; normals are
+// recomputed when the original had them.
+func (a *App) Simplify(meshID string, targetRatio float64) (*Mesh, error) {
+	if !(targetRatio > 0 && targetRatio < 1) {
+		return nil, fmt.Errorf("simplify: target ratio %v must be between 0 and 1", targetRatio)
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	if m.PointCloud || m.TriangleCount() == 0 {
+		m.buffersMu.RUnlock()
+		return nil, fmt.Errorf("simplify: mesh %q has no triangles", meshID)
+	}
+	out := m.simplify(targetRatio)
+	m.buffersMu.RUnlock()
+	return a.meshes.add(out), nil
+}
+
+// quadric is a symmetric 4x4 error matrix stored as its upper triangle:
+// a², ab, ac, ad, b², bc, bd, c², cd, d².
+type quadric [10]float64
+
+// planeQuadric returns w times the squared-distance quadric of the plane
+// through p with unit normal n.
+func planeQuadric(n, p [3]float64, w float64) quadric {
+	a, b, c := n[0], n[1], n[2]
+	d := -dot(n, p)
+	return quadric{
+		w * a * a, w * a * b, w * a * c, w * a * d,
+		w * b * b, w * b * c, w * b * d,
+		w * c * c, w * c * d,
+		w * d * d,
+	}
+}
+
+func (q *quadric) add(o quadric) {
+	for i := range q {
+		q[i] += o[i]
+	}
+}
+
+func (q quadric) eval(p [3]float64) float64 {
+	x, y, z := p[0], p[1], p[2]
+	return q[0]*x*x + 2*q[1]*x*y + 2*q[2]*x*z + 2*q[3]*x +
+		q[4]*y*y + 2*q[5]*y*z + 2*q[6]*y +
+		q[7]*z*z + 2*q[8]*z +
+		q[9]
+}
+
+// optimum returns the point minimising q, or false when the system is
+// singular, as it is for flat or linear neighbourhoods.
+func (q quadric) optimum() ([3]float64, bool) {
+	a, b, c := q[0], q[1], q[2]
+	e, f, i := q[4], q[5], q[7]
+	det := a*(e*i-f*f) - b*(b*i-f*c) + c*(b*f-e*c)
+	if math.Abs(det) < 1e-12 {
+		return [3]float64{}, false
+	}
+	r := [3]float64{-q[3], -q[6], -q[8]}
+	inv := 1 / det
+	return [3]float64{
+		((e*i-f*f)*r[0] + (c*f-b*i)*r[1] + (b*f-c*e)*r[2]) * inv,
+		((c*f-b*i)*r[0] + (a*i-c*c)*r[1] + (b*c-a*f)*r[2]) * inv,
+		((b*f-c*e)*r[0] + (b*c-a*f)*r[1] + (a*e-b*b)*r[2]) * inv,
+	}, true
+}
+
+// collapse is a candidate edge in the priority queue. The stamps record the
+// endpoint versions when it was queued; a mismatch means it is stale.
+type collapse struct {
+	u, v           uint32
+	cost           float64
+	target         [3]float64
+	ustamp, vstamp uint32
+}
+
+type collapseQueue []collapse
+
+func (q collapseQueue) Len() int           { return len(q) }
+func (q collapseQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q collapseQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *collapseQueue) Push(x any)        { *q = append(*q, x.(collapse)) }
+func (q *collapseQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// decimator holds the working state of one simplification. Vertices are
+// indexed by position class, so seams in the source do not tear open.
+type decimator struct {
+	pos      [][3]float64
+	quadrics []quadric
+	stamp    []uint32
+	removed  []bool
+	faces    [][3]uint32
+	// source is the index of the triangle each face came from.
+	source []int
+	dead   []bool
+	// around lists the faces touching each vertex; it may contain dead faces.
+	around [][]int
+	queue  collapseQueue
+}
+
+func (m *Mesh) simplify(ratio float64) *Mesh {
+	canon := m.positionClasses()
+	n := m.VertexCount()
+	d := &decimator{
+		pos:      make([][3]float64, n),
+		quadrics: make([]quadric, n),
+		stamp:    make([]uint32, n),
+		removed:  make([]bool, n),
+		around:   make([][]int, n),
+	}
+	for v := 0; v < n; v++ {
+		d.pos[v] = m.vertex(uint32(v))
+	}
+
+	edgeFaces := make(map[uint64]int)
+	for t := 0; t < m.TriangleCount(); t++ {
+		f := [3]uint32{canon[m.Indices[t*3]], canon[m.Indices[t*3+1]], canon[m.Indices[t*3+2]]}
+		if f[0] == f[1] || f[1] == f[2] || f[0] == f[2] {
+			continue
+		}
+		d.faces = append(d.faces, f)
+		d.source = append(d.source, t)
+		for k := 0; k < 3; k++ {
+			edgeFaces[edgeKey(f[k], f[(k+1)%3])]++
+		}
+	}
+	d.dead = make([]bool, len(d.faces))
+
+	for i, f := range d.faces {
+		p0, p1, p2 := d.pos[f[0]], d.pos[f[1]], d.pos[f[2]]
+		c := cross(sub(p1, p0), sub(p2, p0))
+		area := length(c) / 2
+		if area == 0 {
+			continue
+		}
+		normal := scale(c, 1/(2*area))
+		q := planeQuadric(normal, p0, area)
+		for k := 0; k < 3; k++ {
+			d.quadrics[f[k]].add(q)
+			d.around[f[k]] = append(d.around[f[k]], i)
+		}
+
+		// A plane through each open edge, perpendicular to the face, pulls
+		// boundary vertices back onto the outline.
+		for k := 0; k < 3; k++ {
+			a, b := f[k], f[(k+1)%3]
+			if edgeFaces[edgeKey(a, b)] != 1 {
+				continue
+			}
+			e := sub(d.pos[b], d.pos[a])
+			side := normalize(cross(e, normal))
+			bq := planeQuadric(side, d.pos[a], simplifyBoundaryWeight*dot(e, e))
+			d.quadrics[a].add(bq)
+			d.quadrics[b].add(bq)
+		}
+	}
+
+	for key := range edgeFaces {
+		d.queue = append(d.queue, d.edge(uint32(key>>32), uint32(key)))
+	}
+	heap.Init(&d.queue)
+
+	alive := len(d.faces)
+	target := max(int(float64(m.TriangleCount())*ratio), 1)
+	for alive > target && d.queue.Len() > 0 {
+		c := heap.Pop(&d.queue).(collapse)
+		if d.removed[c.u] || d.removed[c.v] || d.stamp[c.u] != c.ustamp || d.stamp[c.v] != c.vstamp {
+			continue
+		}
+		if !d.canCollapse(c.u, c.v, c.target) {
+			continue
+		}
+		alive -= d.collapse(c.u, c.v, c.target)
+	}
+	return d.mesh(m)
+}
+
+// edge computes the cost and target position of collapsing u-v.
+func (d *decimator) edge(u, v uint32) collapse {
+	q := d.quadrics[u]
+	q.add(d.quadrics[v])
+	target, ok := q.optimum()
+	cost := math.Inf(1)
+	if ok {
+		cost = q.eval(target)
+	}
+	if !ok || math.IsNaN(cost) {
+		mid := scale(add(d.pos[u], d.pos[v]), 0.5)
+		for _, p := range [3][3]float64{d.pos[u], d.pos[v], mid} {
+			if e := q.eval(p); e < cost || math.IsInf(cost, 1) {
+				cost, target = e, p
+			}
+		}
+	}
+	return collapse{u: u, v: v, cost: cost, target: target, ustamp: d.stamp[u], vstamp: d.stamp[v]}
+}
+
+// canCollapse rejects collapses that would make the surface non-manifold or
+// flip a surrounding face.
+func (d *decimator) canCollapse(u, v uint32, target [3]float64) bool {
+	// Link condition: the only vertices adjacent to both ends may be the
+	// opposite corners of the faces sharing the edge.
+	shared, neighbours := 0, make(map[uint32]bool)
+	for _, fi := range d.around[u] {
+		if d.dead[fi] {
+			continue
+		}
+		f := d.faces[fi]
+		if f[0] == v || f[1] == v || f[2] == v {
+			shared++
+		}
+		for _, w := range f {
+			if w != u && w != v {
+				neighbours[w] = true
+			}
+		}
+	}
+	common := 0
+	for _, fi := range d.around[v] {
+		if d.dead[fi] {
+			continue
+		}
+		for _, w := range d.faces[fi] {
+			if w != u && w != v && neighbours[w] {
+				common++
+				neighbours[w] = false
+			}
+		}
+	}
+	if common != shared {
+		return false
+	}
+
+	for _, end := range [2]uint32{u, v} {
+		for _, fi := range d.around[end] {
+			if d.dead[fi] {
+				continue
+			}
+			f := d.faces[fi]
+			if f[0] == u && (f[1] == v || f[2] == v) || f[1] == u && (f[0] == v || f[2] == v) ||
+				f[2] == u && (f[0] == v || f[1] == v) {
+				continue
+			}
+			before := cross(sub(d.pos[f[1]], d.pos[f[0]]), sub(d.pos[f[2]], d.pos[f[0]]))
+			var moved [3][3]float64
+			for k, w := range f {
+				moved[k] = d.pos[w]
+				if w == end {
+					moved[k] = target
+				}
+			}
+			after := cross(sub(moved[1], moved[0]), sub(moved[2], moved[0]))
+			if dot(normalize(before), normalize(after)) < simplifyMinFaceDot {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// collapse merges v into u at target and returns the number of faces removed.
+func (d *decimator) collapse(u, v uint32, target [3]float64) int {
+	d.pos[u] = target
+	d.quadrics[u].add(d.quadrics[v])
+	d.removed[v] = true
+	d.stamp[u]++
+
+	removed := 0
+	for _, fi := range d.around[v] {
+		if d.dead[fi] {
+			continue
+		}
+		f := &d.faces[fi]
+		if f[0] == u || f[1] == u || f[2] == u {
+			d.dead[fi] = true
+			removed++
+			continue
+		}
+		for k := range f {
+			if f[k] == v {
+				f[k] = u
+			}
+		}
+		d.around[u] = append(d.around[u], fi)
+	}
+	d.around[v] = nil
+
+	live := d.around[u][:0]
+	requeued := make(map[uint32]bool)
+	for _, fi := range d.around[u] {
+		if d.dead[fi] {
+			continue
+		}
+		live = append(live, fi)
+		for _, w := range d.faces[fi] {
+			if w != u && !requeued[w] {
+				requeued[w] = true
+				heap.Push(&d.queue, d.edge(u, w))
+			}
+		}
+	}
+	d.around[u] = live
+	return removed
+}
+
+// mesh compacts the surviving vertices and faces into a new Mesh. Colours
+// are taken from each surviving vertex's source.
+func (d *decimator) mesh(src *Mesh) *Mesh {
+	out := src.derived()
+	groups := groupCursor{src: src.Groups}
+	remap := make(map[uint32]uint32)
+	hasColors := len(src.Colors) == len(src.Vertices)/3*4
+	for fi, f := range d.faces {
+		if d.dead[fi] {
+			continue
+		}
+		groups.add(out, d.source[fi], len(out.Indices))
+		for _, v := range f {
+			idx, ok := remap[v]
+			if !ok {
+				idx = uint32(len(remap))
+				remap[v] = idx
+				p := d.pos[v]
+				out.Vertices = append(out.Vertices, float32(p[0]), float32(p[1]), float32(p[2]))
+				if hasColors {
+					out.Colors = append(out.Colors, src.Colors[v*4:v*4+4]...)
+				}
+			}
+			out.Indices = append(out.Indices, idx)
+		}
+	}
+	if len(src.Normals) > 0 {
+		out.smoothNormals()
+	}
+	return out
+}
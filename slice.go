@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Polyline is an ordered list of points. Closed polylines repeat no point;
+// the last connects back to the first.
+type Polyline struct {
+	Points [][3]float64 `json:"points"`
+	Closed bool         `json:"closed"`
+}
+
+// SlicePlane intersects a mesh with the plane through point with the given
+// normal and returns the cross-section contours.
+func (a *App) SlicePlane(meshID string, point [3]float64, normal [3]float64) ([]Polyline, error) {
+	if length(normal) == 0 {
+		return nil, fmt.Errorf("slice: plane normal must not be zero")
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	return m.slice(point, normalize(normal)), nil
+}
+
+// sliceKey identifies a contour point topologically: the crossing of edge
+// a-b, or vertex a itself when a == b. Stitching on keys instead of
+// coordinates joins segments from neighbouring triangles exactly.
+type sliceKey struct{ a, b uint32 }
+
+func edgeSliceKey(a, b uint32) sliceKey {
+	if a > b {
+		a, b = b, a
+	}
+	return sliceKey{a, b}
+}
+
+func (m *Mesh) slice(point, normal [3]float64) []Polyline {
+	canon := m.positionClasses()
+	min, max := m.bounds()
+	// Distances this close to zero are snapped onto the plane so nearly
+	// touching vertices do not produce slivers.
+	eps := length(sub(max, min)) * 1e-9
+
+	dist := make([]float64, m.VertexCount())
+	for v := range dist {
+		if canon[v] == uint32(v) {
+			d := dot(sub(m.vertex(uint32(v)), point), normal)
+			if math.Abs(d) <= eps {
+				d = 0
+			}
+			dist[v] = d
+		}
+	}
+
+	var segments [][2]sliceKey
+	type sides struct{ pos, neg int }
+	inPlane := make(map[uint64]*sides)
+	var inPlaneOrder []uint64
+	for t := 0; t < m.TriangleCount(); t++ {
+		c := [3]uint32{canon[m.Indices[t*3]], canon[m.Indices[t*3+1]], canon[m.Indices[t*3+2]]}
+		if c[0] == c[1] || c[1] == c[2] || c[0] == c[2] {
+			continue
+		}
+		var on []int
+		var crossings []sliceKey
+		for k := 0; k < 3; k++ {
+			a, b := c[k], c[(k+1)%3]
+			if dist[a] == 0 {
+				on = append(on, k)
+			}
+			if dist[a]*dist[b] < 0 {
+				crossings = append(crossings, edgeSliceKey(a, b))
+			}
+		}
+
+		switch len(on) {
+		case 0:
+			if len(crossings) == 2 {
+				segments = append(segments, [2]sliceKey{crossings[0], crossings[1]})
+			}
+		case 1:
+			// A vertex on the plane starts a segment only if the opposite
+			// edge crosses; otherwise the triangle just touches the plane.
+			if len(crossings) == 1 {
+				v := c[on[0]]
+				segments = append(segments, [2]sliceKey{{v, v}, crossings[0]})
+			}
+		case 2:
+			// An edge lying in the plane is shared with a neighbour, so it
+			// is collected and emitted once below.
+			a, b := c[on[0]], c[on[1]]
+			other := dist[c[3-on[0]-on[1]]]
+			key := edgeKey(a, b)
+			s, ok := inPlane[key]
+			if !ok {
+				s = &sides{}
+				inPlane[key] = s
+				inPlaneOrder = append(inPlaneOrder, key)
+			}
+			if other > 0 {
+				s.pos++
+			} else {
+				s.neg++
+			}
+		}
+		// All three corners on the plane: the face is coplanar and its
+		// outline comes from the in-plane edges of its neighbours.
+	}
+
+	// An in-plane edge is part of the section unless the surface merely
+	// folds against the plane there, with every face on one side.
+	for _, key := range inPlaneOrder {
+		s := inPlane[key]
+		if (s.pos > 0 && s.neg > 0) || s.pos+s.neg == 1 {
+			a, b := uint32(key>>32), uint32(key)
+			segments = append(segments, [2]sliceKey{{a, a}, {b, b}})
+		}
+	}
+
+	position := func(k sliceKey) [3]float64 {
+		pa := m.vertex(k.a)
+		if k.a == k.b {
+			return pa
+		}
+		pb := m.vertex(k.b)
+		t := dist[k.a] / (dist[k.a] - dist[k.b])
+		return add(pa, scale(sub(pb, pa), t))
+	}
+	return stitchSegments(segments, position)
+}
+
+// stitchSegments joins segments sharing endpoints into polylines. Open chains
+// are traced from their free ends first so they come out whole; what remains
+// are loops.
+func stitchSegments(segments [][2]sliceKey, position func(sliceKey) [3]float64) []Polyline {
+	adjacent := make(map[sliceKey][]int)
+	seen := make(map[[2]sliceKey]bool)
+	var unique [][2]sliceKey
+	for _, s := range segments {
+		if s[0] == s[1] {
+			continue
+		}
+		key := s
+		if key[1].a < key[0].a || key[1].a == key[0].a && key[1].b < key[0].b {
+			key[0], key[1] = key[1], key[0]
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		adjacent[s[0]] = append(adjacent[s[0]], len(unique))
+		adjacent[s[1]] = append(adjacent[s[1]], len(unique))
+		unique = append(unique, s)
+	}
+
+	used := make([]bool, len(unique))
+	next := func(at sliceKey) (int, bool) {
+		for _, i := range adjacent[at] {
+			if !used[i] {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	trace := func(start sliceKey) Polyline {
+		keys := []sliceKey{start}
+		at := start
+		for {
+			i, ok := next(at)
+			if !ok {
+				break
+			}
+			used[i] = true
+			if unique[i][0] == at {
+				at = unique[i][1]
+			} else {
+				at = unique[i][0]
+			}
+			keys = append(keys, at)
+		}
+		line := Polyline{}
+		if len(keys) > 2 && keys[len(keys)-1] == start {
+			line.Closed = true
+			keys = keys[:len(keys)-1]
+		}
+		line.Points = make([][3]float64, len(keys))
+		for i, k := range keys {
+			line.Points[i] = position(k)
+		}
+		return line
+	}
+
+	lines := []Polyline{}
+	for i, s := range unique {
+		for _, end := range s {
+			if !used[i] && len(adjacent[end])%2 == 1 {
+				lines = append(lines, trace(end))
+			}
+		}
+	}
+	for i, s := range unique {
+		if !used[i] {
+			lines = append(lines, trace(s[0]))
+		}
+	}
+	return lines
+}
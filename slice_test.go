@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// perimeter returns the length of a polyline, including the closing edge.
+func perimeter(p Polyline) float64 {
+	var l float64
+	for i := 1; i < len(p.Points); i++ {
+		l += length(sub(p.Points[i], p.Points[i-1]))
+	}
+	if p.Closed && len(p.Points) > 1 {
+		l += length(sub(p.Points[0], p.Points[len(p.Points)-1]))
+	}
+	return l
+}
+
+func TestSlicePlaneCube(t *testing.T) {
+	a := NewApp(defaultSettings())
+	cube := a.meshes.add(cubeMesh(1, [3]float32{}))
+	tests := []struct {
+		name          string
+		point, normal [3]float64
+		// loops is the number of closed contours and length their total
+		// perimeter; zero loops means the plane only touches the cube.
+		loops  int
+		length float64
+	}{
+		{name: "through the middle", point: [3]float64{0, 0, 0}, normal: [3]float64{0, 0, 1}, loops: 1, length: 4},
+		{name: "through a face plane", point: [3]float64{0, 0, 0.5}, normal: [3]float64{0, 0, 1}, loops: 1, length: 4},
+		{name: "through the bottom face plane", point: [3]float64{0, 0, -0.5}, normal: [3]float64{0, 0, -1}, loops: 1, length: 4},
+		{name: "through three corners", point: [3]float64{0.5, 0.5, -0.5}, normal: [3]float64{1, 1, 1}, loops: 1, length: 3 * math.Sqrt2},
+		{name: "touching one corner", point: [3]float64{0.5, 0.5, 0.5}, normal: [3]float64{1, 1, 1}},
+		{name: "missing the cube", point: [3]float64{0, 0, 2}, normal: [3]float64{0, 0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := a.SlicePlane(cube.ID, tt.point, tt.normal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lines) != tt.loops {
+				t.Fatalf("got %d contours, want %d: %+v", len(lines), tt.loops, lines)
+			}
+			var total float64
+			n := normalize(tt.normal)
+			for _, l := range lines {
+				if !l.Closed {
+					t.Errorf("open contour %+v", l)
+				}
+				for _, p := range l.Points {
+					if d := dot(sub(p, tt.point), n); math.Abs(d) > 1e-6 {
+						t.Errorf("point %v is %v off the plane", p, d)
+					}
+				}
+				total += perimeter(l)
+			}
+			if math.Abs(total-tt.length) > 1e-6 {
+				t.Errorf("perimeter = %v, want %v", total, tt.length)
+			}
+		})
+	}
+}
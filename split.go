@@ -0,0 +1,159 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// MeshComponent is one connected part produced by SplitComponents.
+type MeshComponent struct {
+	ID            string `json:"id"`
+	TriangleCount int    `json:"triangleCount"`
+}
+
+// SplitResult lists the meshes a split produced, largest first.
+type SplitResult struct {
+	Components []MeshComponent `json:"components"`
+	// Discarded is the number of components dropped for having fewer than
+	// the requested minimum of triangles.
+	Discarded int `json:"discarded"`
+}
+
+// SplitComponents separates a mesh into its connected components, each
+// registered as a new mesh; the original stays loaded. Triangles are
+// connected when they share a vertex position, so UV seams do not split a
+// part. Components with fewer than minTriangles triangles, such as scan
+// noise, are discarded; zero keeps them all. Lines and points go with the
+// component they touch and are dropped if they touch none.
+func (a *App) SplitComponents(meshID string, minTriangles int) (*SplitResult, error) {
+	if minTriangles < 0 {
+		return nil, fmt.Errorf("split: invalid minimum triangle count %d", minTriangles)
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	if m.PointCloud || m.TriangleCount() == 0 {
+		m.buffersMu.RUnlock()
+		return nil, fmt.Errorf("split: mesh %q has no triangles", meshID)
+	}
+	parts := m.components()
+	m.buffersMu.RUnlock()
+
+	res := &SplitResult{Components: []MeshComponent{}}
+	for _, part := range parts {
+		if part.TriangleCount() < minTriangles {
+			res.Discarded++
+			continue
+		}
+		a.meshes.add(part)
+		res.Components = append(res.Components, MeshComponent{ID: part.ID, TriangleCount: part.TriangleCount()})
+	}
+	return res, nil
+}
+
+// components returns a mesh per connected component, largest first.
+func (m *Mesh) components() []*Mesh {
+	canon := m.positionClasses()
+	parent := make([]uint32, len(canon))
+	for i := range parent {
+		parent[i] = uint32(i)
+	}
+	find := func(v uint32) uint32 {
+		for parent[v] != v {
+			parent[v] = parent[parent[v]]
+			v = parent[v]
+		}
+		return v
+	}
+	union := func(a, b uint32) {
+		if ra, rb := find(canon[a]), find(canon[b]); ra != rb {
+			parent[rb] = ra
+		}
+	}
+	for t := 0; t < m.TriangleCount(); t++ {
+		union(m.Indices[t*3], m.Indices[t*3+1])
+		union(m.Indices[t*3], m.Indices[t*3+2])
+	}
+
+	// Number the components in order of their first triangle.
+	part := make(map[uint32]int)
+	var tris [][]int
+	for t := 0; t < m.TriangleCount(); t++ {
+		root := find(canon[m.Indices[t*3]])
+		p, ok := part[root]
+		if !ok {
+			p = len(tris)
+			part[root] = p
+			tris = append(tris, nil)
+		}
+		tris[p] = append(tris[p], t)
+	}
+
+	parts := make([]*Mesh, len(tris))
+	for p := range parts {
+		parts[p] = m.subset(tris[p], func(v uint32) bool {
+			q, ok := part[find(canon[v])]
+			return ok && q == p
+		})
+	}
+	slices.SortStableFunc(parts, func(x, y *Mesh) int {
+		return cmp.Compare(y.TriangleCount(), x.TriangleCount())
+	})
+	return parts
+}
+
+// subset copies the given triangles, in order, into a new mesh with only the
+// vertices they use. Lines and points are kept when every vertex they use
+// satisfies keep. Groups are rebuilt as runs over the copied triangles.
+func (m *Mesh) subset(tris []int, keep func(v uint32) bool) *Mesh {
+	hasNormals := len(m.Normals) == len(m.Vertices)
+	hasUVs := len(m.UVs) == m.VertexCount()*2
+	hasColors := len(m.Colors) == m.VertexCount()*4
+	out := m.derived()
+
+	remap := make(map[uint32]uint32)
+	vertex := func(v uint32) uint32 {
+		idx, ok := remap[v]
+		if !ok {
+			idx = uint32(len(remap))
+			remap[v] = idx
+			out.Vertices = append(out.Vertices, m.Vertices[v*3:v*3+3]...)
+			if hasNormals {
+				out.Normals = append(out.Normals, m.Normals[v*3:v*3+3]...)
+			}
+			if hasUVs {
+				out.UVs = append(out.UVs, m.UVs[v*2:v*2+2]...)
+			}
+			if hasColors {
+				out.Colors = append(out.Colors, m.Colors[v*4:v*4+4]...)
+			}
+		}
+		return idx
+	}
+
+	groups := groupCursor{src: m.Groups}
+	for _, t := range tris {
+		groups.add(out, t, len(out.Indices))
+		for k := 0; k < 3; k++ {
+			out.Indices = append(out.Indices, vertex(m.Indices[t*3+k]))
+		}
+		if t < len(m.Attributes) {
+			out.Attributes = append(out.Attributes, m.Attributes[t])
+		}
+	}
+
+	for i := 0; i+1 < len(m.Lines); i += 2 {
+		if keep(m.Lines[i]) && keep(m.Lines[i+1]) {
+			out.Lines = append(out.Lines, vertex(m.Lines[i]), vertex(m.Lines[i+1]))
+		}
+	}
+	for _, v := range m.Points {
+		if keep(v) {
+			out.Points = append(out.Points, vertex(v))
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+// mergeMeshes concatenates meshes into one without welding anything.
+func mergeMeshes(parts ...*Mesh) *Mesh {
+	out := &Mesh{}
+	for _, p := range parts {
+		base := uint32(out.VertexCount())
+		out.Vertices = append(out.Vertices, p.Vertices...)
+		for _, i := range p.Indices {
+			out.Indices = append(out.Indices, base+i)
+		}
+	}
+	return out
+}
+
+// soupMesh gives every triangle of m its own copy of its corners.
+func soupMesh(m *Mesh) *Mesh {
+	out := &Mesh{}
+	for k, i := range m.Indices {
+		out.Vertices = append(out.Vertices, m.Vertices[i*3:i*3+3]...)
+		out.Indices = append(out.Indices, uint32(k))
+	}
+	return out
+}
+
+func TestSplitComponents(t *testing.T) {
+	// A lone triangle stands in for scan noise next to two cubes.
+	speck := &Mesh{Vertices: []float32{9, 9, 9, 9.1, 9, 9, 9, 9.1, 9}, Indices: []uint32{0, 1, 2}}
+	tests := []struct {
+		name         string
+		mesh         *Mesh
+		minTriangles int
+		want         []int
+		discarded    int
+	}{
+		{"two cubes", mergeMeshes(cubeMesh(1, [3]float32{}), cubeMesh(1, [3]float32{3, 0, 0})), 0, []int{12, 12}, 0},
+		{"keeps noise", mergeMeshes(cubeMesh(1, [3]float32{}), speck), 0, []int{12, 1}, 0},
+		{"drops noise", mergeMeshes(speck, cubeMesh(1, [3]float32{}), cubeMesh(1, [3]float32{3, 0, 0})), 2, []int{12, 12}, 1},
+		{"drops every part", mergeMeshes(cubeMesh(1, [3]float32{}), cubeMesh(1, [3]float32{3, 0, 0})), 13, nil, 2},
+		// STL gives every triangle its own corners; shared positions still
+		// hold the cube together.
+		{"triangle soup", soupMesh(cubeMesh(1, [3]float32{})), 0, []int{12}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewApp(defaultSettings())
+			m := a.meshes.add(tt.mesh)
+			res, err := a.SplitComponents(m.ID, tt.minTriangles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Discarded != tt.discarded {
+				t.Errorf("Discarded = %d, want %d", res.Discarded, tt.discarded)
+			}
+			if len(res.Components) != len(tt.want) {
+				t.Fatalf("%d components, want %d", len(res.Components), len(tt.want))
+			}
+			for i, c := range res.Components {
+				if c.TriangleCount != tt.want[i] {
+					t.Errorf("component %d has %d triangles, want %d", i, c.TriangleCount, tt.want[i])
+				}
+				part, err := a.meshes.get(c.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if part.TriangleCount() != c.TriangleCount {
+					t.Errorf("component %d mesh has %d triangles, want %d", i, part.TriangleCount(), c.TriangleCount)
+				}
+			}
+		})
+	}
+
+	a := NewApp(defaultSettings())
+	m := a.meshes.add(cubeMesh(1, [3]float32{}))
+	if _, err := a.SplitComponents(m.ID, -1); err == nil {
+		t.Error("negative minTriangles: want error")
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import "math"
+
+// MeshStats summarises the geometry of a mesh for the info panel.
+type MeshStats struct {
+	Min           [3]float64 `json:"min"`
+	Max           [3]float64 `json:"max"`
+	Dimensions    [3]float64 `json:"dimensions"`
+	Centroid      [3]float64 `json:"centroid"`
+	TriangleCount int        `json:"triangleCount"`
+	VertexCount   int        `json:"vertexCount"`
+	SurfaceArea   float64    `json:"surfaceArea"`
+	// Volume is the absolute enclosed volume; it is only meaningful when
+	// Closed is true.
+	Volume float64 `json:"volume"`
+	// Closed reports whether every edge is shared by exactly two triangles.
+	Closed bool `json:"closed"`
+	// Units is the unit of the lengths above; area and volume are in its
+	// square and cube.
+	Units string `json:"units"`
+	// FlipV reports whether the texture coordinates are currently flipped.
+	FlipV bool `json:"flipV"`
+}
+
+// ComputeStats returns bounding box, area and volume figures for a mesh.
+func (a *App) ComputeStats(meshID string) (*MeshStats, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	return m.stats(), nil
+}
+
+// stats walks the triangles once, reading positions in place so no copy of
+// the vertex buffer is made. The centroid is area-weighted over the surface
+// so it does not depend on vertex density.
+func (m *Mesh) stats() *MeshStats {
+	s := &MeshStats{
+		TriangleCount: m.TriangleCount(),
+		VertexCount:   m.VertexCount(),
+		Units:         m.Units,
+		FlipV:         m.FlipV,
+	}
+
+	min, max := m.bounds()
+	s.Min, s.Max = min, max
+	for i := range s.Dimensions {
+		s.Dimensions[i] = max[i] - min[i]
+	}
+
+	var signedVolume float64
+	for t := 0; t < s.TriangleCount; t++ {
+		p0, p1, p2 := m.triangle(t)
+		c := cross(sub(p1, p0), sub(p2, p0))
+		area := length(c) / 2
+		s.SurfaceArea += area
+		for i := range s.Centroid {
+			s.Centroid[i] += area * (p0[i] + p1[i] + p2[i]) / 3
+		}
+		signedVolume += dot(p0, cross(p1, p2)) / 6
+	}
+	if s.SurfaceArea > 0 {
+		for i := range s.Centroid {
+			s.Centroid[i] /= s.SurfaceArea
+		}
+	} else {
+		for i := range s.Centroid {
+			s.Centroid[i] = (min[i] + max[i]) / 2
+		}
+	}
+	s.Volume = math.Abs(signedVolume)
+	s.Closed = s.TriangleCount > 0 && m.isClosed()
+	return s
+}
+
+// bounds returns the axis-aligned bounding box, or zeros for an empty mesh.
+func (m *Mesh) bounds() (min, max [3]float64) {
+	if len(m.Vertices) < 3 {
+		return min, max
+	}
+	for i := 0; i < 3; i++ {
+		min[i], max[i] = math.Inf(1), math.Inf(-1)
+	}
+	for v := 0; v+2 < len(m.Vertices); v += 3 {
+		for i := 0; i < 3; i++ {
+			x := float64(m.Vertices[v+i])
+			min[i] = math.Min(min[i], x)
+			max[i] = math.Max(max[i], x)
+		}
+	}
+	return min, max
+}
+
+// isClosed reports whether every edge is used by exactly two triangles.
+// Vertices are compared by position so UV or normal seams, which split
+// vertices in the index buffer, do not count as open edges.
+func (m *Mesh) isClosed() bool {
+	canon := m.positionClasses()
+	edges := make(map[uint64]int32, len(m.Indices))
+	for t := 0; t+2 < len(m.Indices); t += 3 {
+		for k := 0; k < 3; k++ {
+			a, b := canon[m.Indices[t+k]], canon[m.Indices[t+(k+1)%3]]
+			edges[edgeKey(a, b)]++
+		}
+	}
+	for _, n := range edges {
+		if n != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// positionClasses maps each vertex to the lowest-numbered vertex sharing its
+// exact position.
+func (m *Mesh) positionClasses() []uint32 {
+	canon := make([]uint32, m.VertexCount())
+	seen := make(map[[3]float32]uint32, len(canon))
+	for v := range canon {
+		p := [3]float32{m.Vertices[v*3], m.Vertices[v*3+1], m.Vertices[v*3+2]}
+		if first, ok := seen[p]; ok {
+			canon[v] = first
+			continue
+		}
+		seen[p] = uint32(v)
+		canon[v] = uint32(v)
+	}
+	return canon
+}
+
+// edgeKey packs an undirected edge into a map key.
+func edgeKey(a, b uint32) uint64 {
+	if a > b {
+		a, b = b, a
+	}
+	return uint64(a)<<32 | uint64(b)
+}
+
+// vertex returns position i in double precision.
+func (m *Mesh) vertex(i uint32) [3]float64 {
+	return [3]float64{float64(m.Vertices[i*3]), float64(m.Vertices[i*3+1]), float64(m.Vertices[i*3+2])}
+}
+
+// triangle returns the corner positions of triangle t.
+func (m *Mesh) triangle(t int) (p0, p1, p2 [3]float64) {
+	return m.vertex(m.Indices[t*3]), m.vertex(m.Indices[t*3+1]), m.vertex(m.Indices[t*3+2])
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// LoadSTL parses an ASCII or binary STL file.
+func (a *App) LoadSTL(path string) (*Mesh, error) {
+	mesh, err := loadFormat(context.Background(), formatForExtension(".stl"), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.modelLoaded(path, mesh), nil
+}
+
+func loadSTL(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("stl: %w", err)
+	}
+	defer f.Close()
+
+	if gzipped(f) {
+		// The binary layout is detected from the exact size, which a
+		// compressed stream does not reveal, so it is inflated up front.
+		data, err := readFileWithProgress(ctx, path, progress)
+		if err != nil {
+			return nil, fmt.Errorf("stl: %w", err)
+		}
+		return parseSTL(bytes.NewReader(data), int64(len(data)), nil)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stl: %w", err)
+	}
+	return parseSTL(&progressReader{ctx: ctx, r: f, hash: contentHashFor(ctx, path)}, info.Size(), progress)
+}
+
+// parseSTL reads an STL stream of the given size. The "solid" keyword is not
+// trusted on its own because many binary exporters start their header with
+// it; a binary layout is assumed whenever the declared triangle count matches
+// the file size exactly, or the leading bytes are not plain text.
+func parseSTL(r io.Reader, size int64, progress progressFunc) (*Mesh, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("stl: file is empty")
+	}
+
+	br := bufio.NewReaderSize(r, 1<<16)
+	head, _ := br.Peek(512)
+	if !isBinarySTL(head, size) {
+		// Cancellation is checked by the reader underneath; this one only
+		// reports progress.
+		return parseASCIISTL(&progressReader{ctx: context.Background(), r: br, total: size, report: progress})
+	}
+	return parseBinarySTL(br, size, progress)
+}
+
+// isBinarySTL decides the STL encoding from the leading bytes and the total
+// size, which may be -1 when unknown.
+func isBinarySTL(head []byte, size int64) bool {
+	if len(head) >= stlHeaderSize+4 {
+		count := int64(binary.LittleEndian.Uint32(head[stlHeaderSize:]))
+		if stlHeaderSize+4+count*stlTriangleSize == size {
+			return true
+		}
+	}
+	return !bytes.HasPrefix(bytes.TrimLeft(head, " \t\r\n"), []byte("solid")) || bytes.IndexByte(head, 0) >= 0
+}
+
+// parseBinarySTL reports progress by triangle index, since the count is
+// known up front.
+func parseBinarySTL(r io.Reader, size int64, progress progressFunc) (*Mesh, error) {
+	var header [stlHeaderSize + 4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("stl: truncated header (%d bytes, need %d)", size, len(header))
+	}
+
+	count := binary.LittleEndian.Uint32(header[stlHeaderSize:])
+	if need := stlHeaderSize + 4 + int64(count)*stlTriangleSize; need > size {
+		return nil, fmt.Errorf("stl: header declares %d triangles (%d bytes) but file has only %d bytes", count, need, size)
+	}
+
+	b := newMeshBuilder(int(count))
+	attrs := make([]uint16, count)
+	hasAttrs := false
+
+	var tri [stlTriangleSize]byte
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(r, tri[:]); err != nil {
+			return nil, fmt.Errorf("stl: truncated at triangle %d of %d", i, count)
+		}
+		// The facet normal (bytes 0-11) is frequently zero or stale in real
+		// files, so it is ignored in favour of the vertex winding.
+		for v := 0; v < 3; v++ {
+			off := 12 + v*12
+			b.add([3]float32{
+				math.Float32frombits(binary.LittleEndian.Uint32(tri[off:])),
+				math.Float32frombits(binary.LittleEndian.Uint32(tri[off+4:])),
+				math.Float32frombits(binary.LittleEndian.Uint32(tri[off+8:])),
+			})
+		}
+		attrs[i] = binary.LittleEndian.Uint16(tri[48:])
+		hasAttrs = hasAttrs || attrs[i] != 0
+
+		if i%4096 == 0 {
+			progress.report(int64(i), int64(count))
+		}
+	}
+	progress.report(int64(count), int64(count))
+
+	m := b.mesh()
+	if hasAttrs {
+		m.Attributes = attrs
+	}
+	if text := cleanMetaText(string(header[:stlHeaderSize])); text != "" {
+		m.Meta = &ModelMeta{Header: text}
+	}
+	return m, nil
+}
+
+func parseASCIISTL(r io.Reader) (*Mesh, error) {
+	b := newMeshBuilder(0)
+	var facet [][3]float32
+	inFacet := false
+	name := ""
+
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "solid":
+			if line == 1 || name == "" {
+				name = strings.Join(fields[1:], " ")
+			}
+		case "facet":
+			inFacet = true
+			facet = facet[:0]
+		case "vertex":
+			if !inFacet {
+				return nil, fmt.Errorf("stl: line %d: vertex outside facet", line)
+			}
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("stl: line %d: vertex expects 3 components", line)
+			}
+			var p [3]float32
+			for i := range p {
+				v, err := strconv.ParseFloat(fields[i+1], 32)
+				if err != nil {
+					return nil, fmt.Errorf("stl: line %d: invalid number %q", line, fields[i+1])
+				}
+				p[i] = float32(v)
+			}
+			facet = append(facet, p)
+		case "endfacet":
+			if len(facet) < 3 {
+				return nil, fmt.Errorf("stl: line %d: facet has %d vertices", line, len(facet))
+			}
+			for i := 1; i+1 < len(facet); i++ {
+				b.add(facet[0])
+				b.add(facet[i])
+				b.add(facet[i+1])
+			}
+			inFacet = false
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("stl: %w", err)
+	}
+	if inFacet {
+		return nil, fmt.Errorf("stl: unexpected end of file inside facet")
+	}
+	if len(b.indices) == 0 {
+		return nil, fmt.Errorf("stl: no facets found")
+	}
+	m := b.mesh()
+	if name = cleanMetaText(name); name != "" {
+		m.Meta = &ModelMeta{Header: name}
+	}
+	return m, nil
+}
+
+// meshBuilder collects triangle corners, merging coincident positions into a
+// shared index buffer.
+type meshBuilder struct {
+	vertices []float32
+	indices  []uint32
+	lookup   map[[3]float32]uint32
+}
+
+func newMeshBuilder(triangles int) *meshBuilder {
+	return &meshBuilder{
+		vertices: make([]float32, 0, triangles*3),
+		indices:  make([]uint32, 0, triangles*3),
+		lookup:   make(map[[3]float32]uint32, triangles/2),
+	}
+}
+
+func (b *meshBuilder) add(p [3]float32) {
+	idx, ok := b.lookup[p]
+	if !ok {
+		idx = uint32(len(b.vertices) / 3)
+		b.vertices = append(b.vertices, p[0], p[1], p[2])
+		b.lookup[p] = idx
+	}
+	b.indices = append(b.indices, idx)
+}
+
+func (b *meshBuilder) mesh() *Mesh {
+	return &Mesh{Vertices: b.vertices, Indices: b.indices}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// quadTriangles are two triangles sharing the edge (1,0,0)-(0,1,0).
+var quadTriangles = [][3][3]float32{
+	{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+	{{1, 0, 0}, {1, 1, 0}, {0, 1, 0}},
+}
+
+// binarySTL encodes tris after an 80-byte header starting with header. A
+// non-negative count overrides the declared triangle count.
+func binarySTL(header string, count int, tris [][3][3]float32) []byte {
+	buf := make([]byte, stlHeaderSize+4)
+	copy(buf, header)
+	if count < 0 {
+		count = len(tris)
+	}
+	binary.LittleEndian.PutUint32(buf[stlHeaderSize:], uint32(count))
+	for _, tri := range tris {
+		buf = append(buf, make([]byte, 12)...)
+		for _, p := range tri {
+			for _, c := range p {
+				buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(c))
+			}
+		}
+		buf = append(buf, 0, 0)
+	}
+	return buf
+}
+
+func asciiSTL(tris [][3][3]float32) []byte {
+	var b bytes.Buffer
+	b.WriteString("solid plate\n")
+	for _, tri := range tris {
+		b.WriteString("facet normal 0 0 1\nouter loop\n")
+		for _, p := range tri {
+			fmt.Fprintf(&b, "vertex %g %g %g\n", p[0], p[1], p[2])
+		}
+		b.WriteString("endloop\nendfacet\n")
+	}
+	b.WriteString("endsolid plate\n")
+	return b.Bytes()
+}
+
+func TestParseSTL(t *testing.T) {
+	quad := []float32{0, 0, 0, 1, 0, 0, 0, 1, 0, 1, 1, 0}
+	tests := []struct {
+		name   string
+		data   []byte
+		header string
+		err    string
+	}{
+		{name: "ascii", data: asciiSTL(quadTriangles), header: "plate"},
+		{name: "binary", data: binarySTL("exported by cad", -1, quadTriangles), header: "exported by cad"},
+		// The size matches the declared count, so the "solid" prefix does
+		// not make it ASCII.
+		{name: "binary starting with solid", data: binarySTL("solid part", -1, quadTriangles), header: "solid part"},
+		{name: "binary declaring too many triangles", data: binarySTL("cad", 3, quadTriangles), err: "header declares 3 triangles"},
+		{name: "empty", data: nil, err: "file is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := parseSTL(bytes.NewReader(tt.data), int64(len(tt.data)), nil)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Corners shared by both triangles are merged.
+			if !slices.Equal(m.Vertices, quad) || !slices.Equal(m.Indices, []uint32{0, 1, 2, 1, 3, 2}) {
+				t.Errorf("vertices = %v, indices = %v", m.Vertices, m.Indices)
+			}
+			if m.Meta == nil || m.Meta.Header != tt.header {
+				t.Errorf("meta = %+v, want header %q", m.Meta, tt.header)
+			}
+		})
+	}
+}
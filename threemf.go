@@ -0,0 +1,388 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	threeMFModelRel = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"
+	// threeMFDefaultModel is where nearly every producer puts the model part;
+	// it is only used when the package has no usable relationships.
+	threeMFDefaultModel = "3D/3dmodel.model"
+)
+
+// threeMFUnits maps the 3MF unit attribute to a viewer unit and the factor
+// that converts coordinates into it. Microns and feet have no viewer unit of
+// their own, so they are rescaled to millimetres and inches.
+var threeMFUnits = map[string]struct {
+	units  string
+	factor float32
+}{
+	"micron":     {"mm", 0.001},
+	"millimeter": {"mm", 1},
+	"centimeter": {"cm", 1},
+	"meter":      {"m", 1},
+	"inch":       {"in", 1},
+	"foot":       {"in", 12},
+}
+
+type threeMFRelationships struct {
+	Relationships []struct {
+		Target string `xml:"Target,attr"`
+		Type   string `xml:"Type,attr"`
+	} `xml:"Relationship"`
+}
+
+type threeMFModel struct {
+	Unit     string `xml:"unit,attr"`
+	Metadata []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"metadata"`
+	Resources struct {
+		BaseMaterials []struct {
+			ID    int `xml:"id,attr"`
+			Bases []struct {
+				Name         string `xml:"name,attr"`
+				DisplayColor string `xml:"displaycolor,attr"`
+			} `xml:"base"`
+		} `xml:"basematerials"`
+		Objects []threeMFObject `xml:"object"`
+	} `xml:"resources"`
+	Build struct {
+		Items []struct {
+			ObjectID  int    `xml:"objectid,attr"`
+			Transform string `xml:"transform,attr"`
+		} `xml:"item"`
+	} `xml:"build"`
+}
+
+type threeMFObject struct {
+	ID     int    `xml:"id,attr"`
+	Name   string `xml:"name,attr"`
+	PID    *int   `xml:"pid,attr"`
+	PIndex int    `xml:"pindex,attr"`
+	Mesh   *struct {
+		Vertices []struct {
+			X float32 `xml:"x,attr"`
+			Y float32 `xml:"y,attr"`
+			Z float32 `xml:"z,attr"`
+		} `xml:"vertices>vertex"`
+		Triangles []struct {
+			V1  uint32 `xml:"v1,attr"`
+			V2  uint32 `xml:"v2,attr"`
+			V3  uint32 `xml:"v3,attr"`
+			PID *int   `xml:"pid,attr"`
+			P1  *int   `xml:"p1,attr"`
+		} `xml:"triangles>triangle"`
+	} `xml:"mesh"`
+	Components []struct {
+		ObjectID  int    `xml:"objectid,attr"`
+		Transform string `xml:"transform,attr"`
+	} `xml:"components>component"`
+}
+
+// Load3MF parses a 3MF package into a scene with one node per build item.
+// Objects assembled from components become node subtrees.
+func (a *App) Load3MF(path string) (*Scene, error) {
+	start := time.Now()
+	ctx, hash := withContentHash(context.Background(), path)
+	scene, err := load3MF(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	scene.Meta = describeFile(scene.Meta, path, formatForExtension(".3mf").name, time.Since(start))
+	scene.Meta.Hash = hash.sum()
+	abs := a.addRecentFile(path, scene.Meta.Hash)
+	a.stopWatchingOthers(abs)
+	a.warnIfHeavy(abs, scene.triangleCount())
+	return a.meshes.addScene(scene), nil
+}
+
+func load3MFMesh(ctx context.Context, path string, progress progressFunc) (*Mesh, error) {
+	scene, err := load3MF(ctx, path, progress)
+	if err != nil {
+		return nil, err
+	}
+	mesh := scene.flatten()
+	mesh.Meta = scene.Meta
+	if len(scene.Meshes) > 0 && len(scene.Meshes[0].Primitives) > 0 {
+		mesh.Units = scene.Meshes[0].Primitives[0].Mesh.Units
+	}
+	return mesh, nil
+}
+
+func load3MF(ctx context.Context, filePath string, progress progressFunc) (*Scene, error) {
+	var zr *zip.Reader
+	if isGzipFile(filePath) {
+		// zip needs random access, which a gzip stream cannot give.
+		data, err := readFileWithProgress(ctx, filePath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("3mf: %w", err)
+		}
+		if zr, err = zip.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+			return nil, fmt.Errorf("3mf: %w", err)
+		}
+	} else {
+		rc, err := zip.OpenReader(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("3mf: %w", err)
+		}
+		defer rc.Close()
+		zr = &rc.Reader
+	}
+
+	part, err := threeMFModelPart(zr)
+	if err != nil {
+		return nil, fmt.Errorf("3mf: %w", err)
+	}
+	rc, err := part.Open()
+	if err != nil {
+		return nil, fmt.Errorf("3mf: %w", err)
+	}
+	defer rc.Close()
+
+	var model threeMFModel
+	r := &progressReader{ctx: ctx, r: rc, total: int64(part.UncompressedSize64), report: progress}
+	if err := xml.NewDecoder(r).Decode(&model); err != nil {
+		return nil, fmt.Errorf("3mf: %s: %w", part.Name, err)
+	}
+	scene, err := model.scene()
+	if err != nil {
+		return nil, fmt.Errorf("3mf: %w", err)
+	}
+	return scene, nil
+}
+
+// threeMFModelPart follows the package relationships to the root model part.
+func threeMFModelPart(zr *zip.Reader) (*zip.File, error) {
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		// Part names are case-insensitive in OPC.
+		files[strings.ToLower(strings.TrimPrefix(f.Name, "/"))] = f
+	}
+
+	target := threeMFDefaultModel
+	if rels, ok := files["_rels/.rels"]; ok {
+		rc, err := rels.Open()
+		if err != nil {
+			return nil, err
+		}
+		var doc threeMFRelationships
+		err = xml.NewDecoder(rc).Decode(&doc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("_rels/.rels: %w", err)
+		}
+		for _, rel := range doc.Relationships {
+			if rel.Type == threeMFModelRel {
+				target = path.Clean(strings.TrimPrefix(rel.Target, "/"))
+				break
+			}
+		}
+	}
+	f, ok := files[strings.ToLower(target)]
+	if !ok {
+		return nil, fmt.Errorf("model part %q not found in package", target)
+	}
+	return f, nil
+}
+
+func (m *threeMFModel) scene() (*Scene, error) {
+	unit := m.Unit
+	if unit == "" {
+		unit = "millimeter"
+	}
+	u, ok := threeMFUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q", m.Unit)
+	}
+
+	s := &Scene{Nodes: []SceneNode{}, Roots: []int{}, Meshes: []SceneMesh{}, Materials: []PBRMaterial{}, Meta: &ModelMeta{}}
+	for _, md := range m.Metadata {
+		s.Meta.addComment(md.Name + ": " + md.Value)
+	}
+	type materialKey struct{ group, index int }
+	materials := make(map[materialKey]int)
+	for _, group := range m.Resources.BaseMaterials {
+		for i, base := range group.Bases {
+			mat := PBRMaterial{
+				Name:                     base.Name,
+				BaseColorFactor:          parseThreeMFColor(base.DisplayColor),
+				MetallicFactor:           0,
+				RoughnessFactor:          1,
+				BaseColorTexture:         -1,
+				MetallicRoughnessTexture: -1,
+				NormalTexture:            -1,
+				OcclusionTexture:         -1,
+				EmissiveTexture:          -1,
+				AlphaMode:                "OPAQUE",
+			}
+			if mat.BaseColorFactor[3] < 1 {
+				mat.AlphaMode = "BLEND"
+			}
+			materials[materialKey{group.ID, i}] = len(s.Materials)
+			s.Materials = append(s.Materials, mat)
+		}
+	}
+	materialFor := func(pid *int, index int) int {
+		if pid == nil {
+			return -1
+		}
+		if idx, ok := materials[materialKey{*pid, index}]; ok {
+			return idx
+		}
+		return -1
+	}
+
+	objects := make(map[int]*threeMFObject, len(m.Resources.Objects))
+	meshIndex := make(map[int]int)
+	for i := range m.Resources.Objects {
+		obj := &m.Resources.Objects[i]
+		objects[obj.ID] = obj
+		if obj.Mesh == nil {
+			continue
+		}
+
+		// Triangles may override the object's material, so geometry is split
+		// into one primitive per material.
+		count := uint32(len(obj.Mesh.Vertices))
+		builders := make(map[int]*Mesh)
+		var order []int
+		remaps := make(map[int]map[uint32]uint32)
+		for t, tri := range obj.Mesh.Triangles {
+			if tri.V1 >= count || tri.V2 >= count || tri.V3 >= count {
+				return nil, fmt.Errorf("object %d: triangle %d references a missing vertex", obj.ID, t)
+			}
+			mat := materialFor(obj.PID, obj.PIndex)
+			if tri.PID != nil || tri.P1 != nil {
+				pid := obj.PID
+				if tri.PID != nil {
+					pid = tri.PID
+				}
+				index := obj.PIndex
+				if tri.P1 != nil {
+					index = *tri.P1
+				}
+				mat = materialFor(pid, index)
+			}
+			b, ok := builders[mat]
+			if !ok {
+				b = &Mesh{Units: u.units}
+				builders[mat] = b
+				remaps[mat] = make(map[uint32]uint32)
+				order = append(order, mat)
+			}
+			remap := remaps[mat]
+			for _, v := range [3]uint32{tri.V1, tri.V2, tri.V3} {
+				idx, ok := remap[v]
+				if !ok {
+					idx = uint32(b.VertexCount())
+					remap[v] = idx
+					p := obj.Mesh.Vertices[v]
+					b.Vertices = append(b.Vertices, p.X*u.factor, p.Y*u.factor, p.Z*u.factor)
+				}
+				b.Indices = append(b.Indices, idx)
+			}
+		}
+
+		sm := SceneMesh{Name: obj.Name, Primitives: []Primitive{}}
+		for _, mat := range order {
+			sm.Primitives = append(sm.Primitives, Primitive{Mesh: builders[mat], Material: mat, Mode: gltfTriangles})
+		}
+		meshIndex[obj.ID] = len(s.Meshes)
+		s.Meshes = append(s.Meshes, sm)
+	}
+
+	// instantiate adds a node for an object and, recursively, its components.
+	// Objects may be referenced many times, so each use gets its own node.
+	var instantiate func(id int, transform string, depth int) (int, error)
+	instantiate = func(id int, transform string, depth int) (int, error) {
+		obj, ok := objects[id]
+		if !ok {
+			return -1, fmt.Errorf("reference to unknown object %d", id)
+		}
+		if depth > len(objects) {
+			return -1, fmt.Errorf("object %d: cyclic component references", id)
+		}
+		matrix, err := parseThreeMFTransform(transform, u.factor)
+		if err != nil {
+			return -1, fmt.Errorf("object %d: %w", id, err)
+		}
+		node := SceneNode{Name: obj.Name, Children: []int{}, Mesh: -1, Rotation: [4]float64{0, 0, 0, 1}, Scale: [3]float64{1, 1, 1}, Matrix: matrix}
+		if idx, ok := meshIndex[id]; ok {
+			node.Mesh = idx
+		}
+		self := len(s.Nodes)
+		s.Nodes = append(s.Nodes, node)
+		for _, c := range obj.Components {
+			child, err := instantiate(c.ObjectID, c.Transform, depth+1)
+			if err != nil {
+				return -1, err
+			}
+			s.Nodes[self].Children = append(s.Nodes[self].Children, child)
+		}
+		return self, nil
+	}
+	for _, item := range m.Build.Items {
+		root, err := instantiate(item.ObjectID, item.Transform, 0)
+		if err != nil {
+			return nil, fmt.Errorf("build item: %w", err)
+		}
+		s.Roots = append(s.Roots, root)
+	}
+	return s, nil
+}
+
+// parseThreeMFTransform converts a 3MF "m00 m01 m02 m10 ... m32" affine
+// transform, which applies to row vectors, into a column-major matrix. The
+// translation is scaled like the vertices. An empty attribute is identity.
+func parseThreeMFTransform(s string, unitFactor float32) (*[16]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 12 {
+		return nil, fmt.Errorf("transform has %d values, want 12", len(fields))
+	}
+	var v [12]float64
+	for i, f := range fields {
+		x, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform value %q", f)
+		}
+		v[i] = x
+	}
+	k := float64(unitFactor)
+	return &[16]float64{
+		v[0], v[1], v[2], 0,
+		v[3], v[4], v[5], 0,
+		v[6], v[7], v[8], 0,
+		v[9] * k, v[10] * k, v[11] * k, 1,
+	}, nil
+}
+
+// parseThreeMFColor decodes #RRGGBB or #RRGGBBAA, defaulting to white.
+func parseThreeMFColor(s string) [4]float64 {
+	c := [4]float64{1, 1, 1, 1}
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return c
+	}
+	for i := 0; i*2 < len(hex); i++ {
+		n, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return [4]float64{1, 1, 1, 1}
+		}
+		c[i] = float64(n) / 255
+	}
+	return c
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// write3MF packages model as the root model part of a 3MF file in dir.
+func write3MF(t *testing.T, dir, model string) string {
+	t.Helper()
+	path := filepath.Join(dir, "part.3mf")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	parts := map[string]string{
+		"_rels/.rels":         `<Relationships><Relationship Target="/3D/model.model" Type="` + threeMFModelRel + `"/></Relationships>`,
+		"3D/model.model":      model,
+		"[Content_Types].xml": `<Types/>`,
+	}
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// threeMFAssembly is a triangle object used twice by an assembly object:
+// once in place and once moved 10 units along X. The build item moves the
+// whole assembly 100 units along Y.
+const threeMFAssembly = `<model unit="%s">
+	<resources>
+		<basematerials id="5"><base name="red" displaycolor="#FF0000"/><base name="glass" displaycolor="#0000FF80"/></basematerials>
+		<object id="1" name="tri" pid="5" pindex="0">
+			<mesh>
+				<vertices><vertex x="0" y="0" z="0"/><vertex x="1" y="0" z="0"/><vertex x="0" y="1" z="0"/></vertices>
+				<triangles><triangle v1="0" v2="1" v3="2"/><triangle v1="0" v2="2" v3="1" p1="1"/></triangles>
+			</mesh>
+		</object>
+		<object id="2" name="assembly">
+			<components>
+				<component objectid="1"/>
+				<component objectid="1" transform="1 0 0 0 1 0 0 0 1 10 0 0"/>
+			</components>
+		</object>
+	</resources>
+	<build><item objectid="2" transform="1 0 0 0 1 0 0 0 1 0 100 0"/></build>
+</model>`
+
+func TestLoad3MF(t *testing.T) {
+	tests := []struct {
+		unit, want string
+		factor     float64
+		err        string
+	}{
+		{unit: "millimeter", want: "mm", factor: 1},
+		{unit: "micron", want: "mm", factor: 0.001},
+		{unit: "inch", want: "in", factor: 1},
+		{unit: "foot", want: "in", factor: 12},
+		{unit: "furlong", err: `unknown unit "furlong"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			path := write3MF(t, t.TempDir(), fmt.Sprintf(threeMFAssembly, tt.unit))
+			scene, err := load3MF(context.Background(), path, nil)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// One mesh with a primitive per material, instanced by two
+			// component nodes under the build item's node.
+			if len(scene.Meshes) != 1 || len(scene.Meshes[0].Primitives) != 2 {
+				t.Fatalf("meshes = %+v", scene.Meshes)
+			}
+			if len(scene.Roots) != 1 || len(scene.Nodes[scene.Roots[0]].Children) != 2 {
+				t.Fatalf("roots %v, nodes %+v", scene.Roots, scene.Nodes)
+			}
+			if got := scene.Meshes[0].Primitives[0].Mesh.Units; got != tt.want {
+				t.Errorf("units = %q, want %q", got, tt.want)
+			}
+			if red, glass := scene.Materials[0], scene.Materials[1]; red.Name != "red" || glass.AlphaMode != "BLEND" {
+				t.Errorf("materials = %+v", scene.Materials)
+			}
+
+			flat := scene.flatten()
+			if flat.TriangleCount() != 4 {
+				t.Fatalf("flattened triangles = %d, want 4", flat.TriangleCount())
+			}
+			lo, hi := flat.bounds()
+			wantLo := [3]float64{0, 100 * tt.factor, 0}
+			wantHi := [3]float64{11 * tt.factor, 101 * tt.factor, 0}
+			for k := 0; k < 3; k++ {
+				if math.Abs(lo[k]-wantLo[k]) > 1e-4 || math.Abs(hi[k]-wantHi[k]) > 1e-4 {
+					t.Fatalf("bounds = %v to %v, want %v to %v", lo, hi, wantLo, wantHi)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+)
+
+const (
+	defaultThumbnailSize = 256
+	maxThumbnailSize     = 1024
+
+	// thumbnailSupersample renders at a multiple of the output size and box
+	// filters down, which is a cheap way to smooth edges.
+	thumbnailSupersample = 2
+	// thumbnailMargin is the fraction of the frame left empty on each side.
+	thumbnailMargin = 0.06
+)
+
+var (
+	thumbnailBackground = color.RGBA{R: 232, G: 234, B: 237, A: 255}
+	thumbnailBaseColor  = [3]float64{0.62, 0.66, 0.72}
+	// thumbnailViewDir points from the model towards the camera.
+	thumbnailViewDir = normalize([3]float64{1, 0.8, 1})
+	thumbnailLight   = normalize([3]float64{0.6, 1, 0.8})
+)
+
+// GenerateThumbnail renders a shaded preview of the model at path and returns
+// it as PNG bytes. Rendering happens entirely on the CPU, so it is safe to
+// call from any goroutine and does not touch the window.
+func (a *App) GenerateThumbnail(path string, size int) ([]byte, error) {
+	mesh, err := loadModel(context.Background(), path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return renderThumbnail(mesh, size)
+}
+
+// renderThumbnail draws m with an orthographic three-quarter view fitted to
+// its bounding box and Lambert shading.
+func renderThumbnail(m *Mesh, size int) ([]byte, error) {
+	if size <= 0 {
+		size = defaultThumbnailSize
+	}
+	if size > maxThumbnailSize {
+		return nil, fmt.Errorf("thumbnail size %d exceeds maximum of %d", size, maxThumbnailSize)
+	}
+
+	r := newRasterizer(size*thumbnailSupersample, m)
+	for t := 0; t < m.TriangleCount(); t++ {
+		r.drawTriangle(m.triangle(t))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, r.downsample(thumbnailSupersample)); err != nil {
+		return nil, fmt.Errorf("thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// rasterizer is a minimal z-buffered triangle renderer.
+type rasterizer struct {
+	size   int
+	pixels []float64 // RGB triples
+	depth  []float64
+
+	center          [3]float64
+	right, up, view [3]float64
+	scale           float64
+}
+
+func newRasterizer(size int, m *Mesh) *rasterizer {
+	r := &rasterizer{
+		size:   size,
+		pixels: make([]float64, size*size*3),
+		depth:  make([]float64, size*size),
+		view:   thumbnailViewDir,
+	}
+	bg := [3]float64{float64(thumbnailBackground.R), float64(thumbnailBackground.G), float64(thumbnailBackground.B)}
+	for i := range r.depth {
+		r.depth[i] = math.Inf(-1)
+		copy(r.pixels[i*3:], bg[:])
+	}
+
+	r.right = normalize(cross([3]float64{0, 1, 0}, r.view))
+	r.up = cross(r.view, r.right)
+
+	min, max := m.bounds()
+	r.center = scale(add(min, max), 0.5)
+
+	// Fit the projected bounding box corners rather than the bounding sphere
+	// so flat or elongated models fill the frame.
+	var extent float64
+	for i := 0; i < 8; i++ {
+		corner := [3]float64{min[0], min[1], min[2]}
+		for axis := 0; axis < 3; axis++ {
+			if i&(1<<axis) != 0 {
+				corner[axis] = max[axis]
+			}
+		}
+		d := sub(corner, r.center)
+		extent = math.Max(extent, math.Max(math.Abs(dot(d, r.right)), math.Abs(dot(d, r.up))))
+	}
+	if extent == 0 {
+		extent = 1
+	}
+	r.scale = float64(size) * (0.5 - thumbnailMargin) / extent
+	return r
+}
+
+// project maps a world position to pixel coordinates and a depth that grows
+// towards the camera.
+func (r *rasterizer) project(p [3]float64) (x, y, z float64) {
+	d := sub(p, r.center)
+	half := float64(r.size) / 2
+	return half + dot(d, r.right)*r.scale, half - dot(d, r.up)*r.scale, dot(d, r.view)
+}
+
+func (r *rasterizer) drawTriangle(p0, p1, p2 [3]float64) {
+	n := normalize(cross(sub(p1, p0), sub(p2, p0)))
+	// Shade both sides: winding in real-world files is unreliable.
+	lambert := math.Abs(dot(n, thumbnailLight))
+	shade := 0.35 + 0.65*lambert
+
+	x0, y0, z0 := r.project(p0)
+	x1, y1, z1 := r.project(p1)
+	x2, y2, z2 := r.project(p2)
+
+	area := (x1-x0)*(y2-y0) - (x2-x0)*(y1-y0)
+	if area == 0 || math.IsNaN(area) {
+		return
+	}
+
+	minX := max(int(math.Floor(math.Min(x0, math.Min(x1, x2)))), 0)
+	maxX := min(int(math.Ceil(math.Max(x0, math.Max(x1, x2)))), r.size-1)
+	minY := max(int(math.Floor(math.Min(y0, math.Min(y1, y2)))), 0)
+	maxY := min(int(math.Ceil(math.Max(y0, math.Max(y1, y2)))), r.size-1)
+
+	for py := minY; py <= maxY; py++ {
+		for px := minX; px <= maxX; px++ {
+			cx, cy := float64(px)+0.5, float64(py)+0.5
+			w0 := ((x1-cx)*(y2-cy) - (x2-cx)*(y1-cy)) / area
+			w1 := ((x2-cx)*(y0-cy) - (x0-cx)*(y2-cy)) / area
+			w2 := 1 - w0 - w1
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+			z := w0*z0 + w1*z1 + w2*z2
+			i := py*r.size + px
+			if z <= r.depth[i] {
+				continue
+			}
+			r.depth[i] = z
+			for c := 0; c < 3; c++ {
+				r.pixels[i*3+c] = 255 * thumbnailBaseColor[c] * shade
+			}
+		}
+	}
+}
+
+// downsample box-filters the framebuffer by factor into an image.
+func (r *rasterizer) downsample(factor int) *image.RGBA {
+	out := r.size / factor
+	img := image.NewRGBA(image.Rect(0, 0, out, out))
+	norm := float64(factor * factor)
+	for y := 0; y < out; y++ {
+		for x := 0; x < out; x++ {
+			var sum [3]float64
+			for sy := 0; sy < factor; sy++ {
+				for sx := 0; sx < factor; sx++ {
+					i := ((y*factor+sy)*r.size + x*factor + sx) * 3
+					sum[0] += r.pixels[i]
+					sum[1] += r.pixels[i+1]
+					sum[2] += r.pixels[i+2]
+				}
+			}
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(sum[0] / norm),
+				G: uint8(sum[1] / norm),
+				B: uint8(sum[2] / norm),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Transform is an affine transform baked into a mesh's vertices.
+type Transform struct {
+	// Translation is the offset part of Matrix.
+	Translation [3]float64 `json:"translation"`
+	// Matrix is the whole transform as a column-major 4x4 matrix.
+	Matrix [16]float64 `json:"matrix"`
+}
+
+func newTransform(m mat4) *Transform {
+	return &Transform{Translation: [3]float64{m[12], m[13], m[14]}, Matrix: m}
+}
+
+// ApplyTransform multiplies every vertex of a mesh by a column-major 4x4
+// matrix and normals by the inverse-transpose of its upper 3x3 block. The
+// matrix must be affine and invertible, so the move can be undone; mirroring
+// matrices also reverse the triangle winding so faces keep pointing out. It
+// composes with CenterModel and ConvertUnits, ClearTransform undoes it, and
+// model:updated carries the changed mesh.
+func (a *App) ApplyTransform(meshID string, matrix [16]float64) (*Mesh, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	mat := mat4(matrix)
+	if mat[3] != 0 || mat[7] != 0 || mat[11] != 0 || mat[15] != 1 {
+		return nil, fmt.Errorf("transform: matrix is not affine; the bottom row must be 0 0 0 1")
+	}
+	for _, v := range mat {
+		if !isFinite(v) {
+			return nil, fmt.Errorf("transform: matrix has a non-finite element")
+		}
+	}
+	if _, ok := mat.normalMatrix(); !ok {
+		return nil, fmt.Errorf("transform: matrix is not invertible")
+	}
+	m.transform(mat)
+	a.modelMoved(m)
+	return m, nil
+}
+
+// ClearTransform moves a mesh back to where it was loaded, undoing every
+// CenterModel and ApplyTransform call since.
+func (a *App) ClearTransform(meshID string) error {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	cleared, ok := m.clearTransform()
+	if !ok {
+		return fmt.Errorf("transform: mesh %q has a singular transform", meshID)
+	}
+	if cleared {
+		a.modelMoved(m)
+	}
+	return nil
+}
+
+func (a *App) modelMoved(m *Mesh) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelUpdated, m.ID, m.descriptor())
+	}
+}
+
+// transform bakes mat into the vertices and normals and accumulates it in
+// m.Transform, which is nil while the total is the identity.
+func (m *Mesh) transform(mat mat4) {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	m.transformLocked(mat)
+}
+
+// clearTransform undoes m.Transform. It reports whether there was one to
+// undo, and false for ok if it is singular.
+func (m *Mesh) clearTransform() (cleared, ok bool) {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	if m.Transform == nil {
+		return false, true
+	}
+	inv, ok := mat4(m.Transform.Matrix).affineInverse()
+	if !ok {
+		return false, false
+	}
+	m.transformLocked(inv)
+	// Rounding can leave the total a hair off the identity.
+	m.Transform = nil
+	return true, true
+}
+
+// transformLocked is transform for callers holding buffersMu.
+func (m *Mesh) transformLocked(mat mat4) {
+	for v := 0; v+2 < len(m.Vertices); v += 3 {
+		p := mat.transformPoint([3]float64{float64(m.Vertices[v]), float64(m.Vertices[v+1]), float64(m.Vertices[v+2])})
+		m.Vertices[v], m.Vertices[v+1], m.Vertices[v+2] = float32(p[0]), float32(p[1]), float32(p[2])
+	}
+	if normalMat, ok := mat.normalMatrix(); ok && !mat.isTranslation() {
+		for v := 0; v+2 < len(m.Normals); v += 3 {
+			n := transformNormal(normalMat, [3]float64{float64(m.Normals[v]), float64(m.Normals[v+1]), float64(m.Normals[v+2])})
+			m.Normals[v], m.Normals[v+1], m.Normals[v+2] = float32(n[0]), float32(n[1]), float32(n[2])
+		}
+	}
+	if mat.det3() < 0 {
+		for t := 0; t+2 < len(m.Indices); t += 3 {
+			m.Indices[t+1], m.Indices[t+2] = m.Indices[t+2], m.Indices[t+1]
+		}
+	}
+
+	total := mat
+	if m.Transform != nil {
+		total = mat.mul(mat4(m.Transform.Matrix))
+	}
+	m.Transform = newTransform(total)
+	if total == identityMat4() {
+		m.Transform = nil
+	}
+}
+
+// rescaleTransform converts m.Transform to coordinates scaled by factor, as after a
+// unit conversion: the linear part is unchanged and the offset scales.
+func (m *Mesh) rescaleTransform(factor float64) {
+	if m.Transform == nil {
+		return
+	}
+	mat := mat4(m.Transform.Matrix)
+	for i := 12; i < 15; i++ {
+		mat[i] *= factor
+	}
+	m.Transform = newTransform(mat)
+}
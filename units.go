@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// defaultUnits applies when the user has not chosen a preference. Most
+// unitless formats, STL in particular, come from CAD and slicer tools that
+// work in millimetres.
+const defaultUnits = "mm"
+
+// unitMetres gives the length of one unit in metres.
+var unitMetres = map[string]float64{
+	"mm": 0.001,
+	"cm": 0.01,
+	"m":  1,
+	"in": 0.0254,
+}
+
+func parseUnits(units string) (string, error) {
+	u := strings.ToLower(strings.TrimSpace(units))
+	if _, ok := unitMetres[u]; !ok {
+		return "", fmt.Errorf("units: unknown unit %q (want mm, cm, m or in)", units)
+	}
+	return u, nil
+}
+
+// SetModelUnits declares which unit a mesh's coordinates are in without
+// changing them. Stats are reported in that unit afterwards. For meshes
+// loaded from a file the choice is remembered and applied whenever the same
+// content is opened again.
+func (a *App) SetModelUnits(meshID string, units string) error {
+	u, err := parseUnits(units)
+	if err != nil {
+		return err
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	m.buffersMu.Lock()
+	m.Units = u
+	m.buffersMu.Unlock()
+	return a.rememberUnits(m, u)
+}
+
+// rememberUnits records that the file behind m has its coordinates in u,
+// replacing any choice saved for it under an earlier path or content.
+func (a *App) rememberUnits(m *Mesh, u string) error {
+	if m.Meta == nil || m.Meta.Path == "" {
+		return nil
+	}
+	a.mu.Lock()
+	if a.settings.ModelUnits == nil {
+		a.settings.ModelUnits = make(map[string]string)
+	}
+	if _, old, ok := lookupModel(a.settings.ModelUnits, m.Meta.Path, m.Meta.Hash); ok {
+		delete(a.settings.ModelUnits, old)
+	}
+	a.settings.ModelUnits[modelKey(m.Meta.Path, m.Meta.Hash)] = u
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+// ConvertUnits rescales a mesh's coordinates from one unit to another and
+// emits model:rescaled with its updated stats. An empty from means the
+// mesh's current unit. An explicit from also declares the unit of the
+// model's file, remembered as SetModelUnits does, so reopening or reloading
+// it applies the same conversion rather than mislabelling the file's
+// coordinates with the converted unit.
+func (a *App) ConvertUnits(meshID string, from string, to string) error {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	declared := from != ""
+	if !declared {
+		m.buffersMu.RLock()
+		from = m.Units
+		m.buffersMu.RUnlock()
+	}
+	src, err := parseUnits(from)
+	if err != nil {
+		return err
+	}
+	dst, err := parseUnits(to)
+	if err != nil {
+		return err
+	}
+
+	m.convertUnits(src, dst)
+	if a.ctx != nil {
+		m.buffersMu.RLock()
+		stats := m.stats()
+		m.buffersMu.RUnlock()
+		runtime.EventsEmit(a.ctx, eventModelRescaled, meshID, stats)
+	}
+	if declared {
+		return a.rememberUnits(m, src)
+	}
+	return nil
+}
+
+// convertUnits rescales m from src to dst, which must both be known units,
+// and leaves m in dst.
+func (m *Mesh) convertUnits(src, dst string) {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	m.Units = src
+	// Uniform scaling leaves normals unchanged; lengths scale linearly, so
+	// area and volume in the stats follow quadratically and cubically.
+	factor := unitMetres[m.Units] / unitMetres[dst]
+	if factor != 1 {
+		for i := range m.Vertices {
+			m.Vertices[i] *= float32(factor)
+		}
+		// Any baked transform is restated in the new unit, so ClearTransform
+		// still returns to the loaded shape, now rescaled.
+		m.rescaleTransform(factor)
+	}
+	m.Units = dst
+}
+
+// GetDefaultUnits returns the unit assumed for newly loaded unitless models.
+func (a *App) GetDefaultUnits() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.settings.DefaultUnits == "" {
+		return defaultUnits
+	}
+	return a.settings.DefaultUnits
+}
+
+// SetDefaultUnits stores the unit preference for models loaded from now on.
+func (a *App) SetDefaultUnits(units string) error {
+	u, err := parseUnits(units)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.settings.DefaultUnits = u
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+// assignUnits applies the unit chosen earlier for the model's content, or
+// else gives m the default unit unless its format defines one.
+func (a *App) assignUnits(m *Mesh) {
+	if m.Meta != nil {
+		a.mu.Lock()
+		u, _, ok := lookupModel(a.settings.ModelUnits, m.Meta.Path, m.Meta.Hash)
+		a.mu.Unlock()
+		if ok {
+			m.Units = u
+			return
+		}
+	}
+	if m.Units == "" {
+		m.Units = a.GetDefaultUnits()
+	}
+}
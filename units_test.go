@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertUnitsWithoutRuntime(t *testing.T) {
+	a := NewApp(defaultSettings())
+	m := a.meshes.add(&Mesh{Vertices: []float32{0, 0, 0, 25.4, 0, 0, 0, 25.4, 0}, Indices: []uint32{0, 1, 2}, Units: "mm"})
+	if err := a.ConvertUnits(m.ID, "", "in"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Units != "in" || m.Vertices[3] != 1 {
+		t.Errorf("after conversion x = %v %s, want 1 in", m.Vertices[3], m.Units)
+	}
+}
+
+func TestConvertUnitsRemembersDeclaredUnit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "tri.obj")
+	if err := os.WriteFile(path, []byte("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := NewApp(loadSettings())
+	m, err := a.openModel(context.Background(), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ConvertUnits(m.ID, "in", "mm"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Units != "mm" || m.Vertices[3] != 25.4 {
+		t.Errorf("after conversion x = %v %s, want 25.4 mm", m.Vertices[3], m.Units)
+	}
+	// Converting from the current unit declares nothing new.
+	if err := a.ConvertUnits(m.ID, "", "cm"); err != nil {
+		t.Fatal(err)
+	}
+	if u := a.settings.ModelUnits[modelKey(m.Meta.Path, m.Meta.Hash)]; u != "in" || len(a.settings.ModelUnits) != 1 {
+		t.Errorf("saved model units %v, want in", a.settings.ModelUnits)
+	}
+
+	// The file is in inches from now on, even in a fresh session.
+	b := NewApp(loadSettings())
+	again, err := b.openModel(context.Background(), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Units != "in" || again.Vertices[3] != 1 {
+		t.Errorf("reopened x = %v %s, want 1 in", again.Vertices[3], again.Units)
+	}
+	reloaded, err := a.ReloadModel(m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Units != "cm" || reloaded.Vertices[3] != m.Vertices[3] {
+		t.Errorf("reloaded x = %v %s, want %v cm", reloaded.Vertices[3], reloaded.Units, m.Vertices[3])
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// SetFlipV selects whether a mesh's texture coordinates use the flipped V
+// convention (v' = 1 - v), for models whose textures appear upside down.
+// Models load unflipped. The UVs are changed in place, so stats, buffers and
+// exports see the current state, and model:updated carries the mesh.
+func (a *App) SetFlipV(meshID string, flip bool) error {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	m.buffersMu.RLock()
+	hasUVs := len(m.UVs) > 0
+	m.buffersMu.RUnlock()
+	if !hasUVs {
+		return fmt.Errorf("flip v: mesh %q has no texture coordinates", meshID)
+	}
+	if m.setFlipV(flip) && a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelUpdated, meshID, m.descriptor())
+	}
+	return nil
+}
+
+// setFlipV flips the V coordinates if flip differs from the current state,
+// and reports whether it did.
+func (m *Mesh) setFlipV(flip bool) bool {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	if m.FlipV == flip {
+		return false
+	}
+	for i := 1; i < len(m.UVs); i += 2 {
+		m.UVs[i] = 1 - m.UVs[i]
+	}
+	m.FlipV = flip
+	return true
+}
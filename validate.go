@@ -0,0 +1,161 @@
+package main
+
+import "math"
+
+const (
+	// maxProblemEdges caps how many offending edges are listed in a report.
+	maxProblemEdges = 100
+	// duplicateTolerance is the distance, relative to the bounding box
+	// diagonal, under which two distinct positions count as duplicates.
+	duplicateTolerance = 1e-6
+)
+
+// ValidationReport lists the defects that make a mesh unsuitable for 3D
+// printing. Edges are compared by position, so index buffer splits at UV or
+// normal seams are not reported.
+type ValidationReport struct {
+	// Watertight is set when every edge is shared by exactly two triangles.
+	Watertight       bool `json:"watertight"`
+	NonManifoldEdges int  `json:"nonManifoldEdges"`
+	BoundaryEdges    int  `json:"boundaryEdges"`
+	// DuplicateVertices counts vertices lying within tolerance of another
+	// vertex at a different position.
+	DuplicateVertices int `json:"duplicateVertices"`
+	// SeamVertices counts vertices at exactly the position of an earlier
+	// vertex, as UV or normal seams and unwelded triangle soups leave them.
+	SeamVertices        int `json:"seamVertices"`
+	DegenerateTriangles int `json:"degenerateTriangles"`
+	IsolatedVertices    int `json:"isolatedVertices"`
+	// ProblemEdges holds the first boundary and non-manifold edges found.
+	ProblemEdges []ProblemEdge `json:"problemEdges"`
+}
+
+// ProblemEdge is an edge between vertex indices A and B used by Faces
+// triangles. Kind is "boundary" or "non-manifold".
+type ProblemEdge struct {
+	A     uint32 `json:"a"`
+	B     uint32 `json:"b"`
+	Faces int    `json:"faces"`
+	Kind  string `json:"kind"`
+}
+
+// ValidateMesh analyses a loaded mesh without modifying it.
+func (a *App) ValidateMesh(meshID string) (*ValidationReport, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	defer m.buffersMu.RUnlock()
+	return m.validate(), nil
+}
+
+func (m *Mesh) validate() *ValidationReport {
+	r := &ValidationReport{ProblemEdges: []ProblemEdge{}}
+	canon := m.positionClasses()
+	min, max := m.bounds()
+	diag := length(sub(max, min))
+
+	used := make([]bool, m.VertexCount())
+	edges := make(map[uint64]int, len(m.Indices))
+	var order []uint64
+	for t := 0; t < m.TriangleCount(); t++ {
+		p0, p1, p2 := m.triangle(t)
+		// Compare doubled area against the diagonal so the test scales with
+		// the model.
+		if length(cross(sub(p1, p0), sub(p2, p0))) <= diag*diag*1e-12 {
+			r.DegenerateTriangles++
+		}
+		c := [3]uint32{canon[m.Indices[t*3]], canon[m.Indices[t*3+1]], canon[m.Indices[t*3+2]]}
+		for k := 0; k < 3; k++ {
+			used[m.Indices[t*3+k]] = true
+		}
+		// A triangle with a repeated corner has no real edges to count.
+		if c[0] == c[1] || c[1] == c[2] || c[0] == c[2] {
+			continue
+		}
+		for k := 0; k < 3; k++ {
+			key := edgeKey(c[k], c[(k+1)%3])
+			if edges[key] == 0 {
+				order = append(order, key)
+			}
+			edges[key]++
+		}
+	}
+
+	// Walk edges in first-seen order so the listed problems are stable.
+	for _, key := range order {
+		n := edges[key]
+		if n == 2 {
+			continue
+		}
+		kind := "boundary"
+		if n == 1 {
+			r.BoundaryEdges++
+		} else {
+			r.NonManifoldEdges++
+			kind = "non-manifold"
+		}
+		if len(r.ProblemEdges) < maxProblemEdges {
+			r.ProblemEdges = append(r.ProblemEdges, ProblemEdge{A: uint32(key >> 32), B: uint32(key), Faces: n, Kind: kind})
+		}
+	}
+	r.Watertight = m.TriangleCount() > 0 && r.BoundaryEdges == 0 && r.NonManifoldEdges == 0
+
+	for v, u := range used {
+		if !u {
+			r.IsolatedVertices++
+		}
+		if canon[v] != uint32(v) {
+			r.SeamVertices++
+		}
+	}
+	r.DuplicateVertices = m.nearDuplicates(canon, diag*duplicateTolerance)
+	return r
+}
+
+// nearDuplicates counts vertices within tol of a vertex at a different
+// position, using a uniform grid of tol-sized cells so only neighbouring
+// cells are compared.
+func (m *Mesh) nearDuplicates(canon []uint32, tol float64) int {
+	if tol <= 0 {
+		return 0
+	}
+	grid := make(map[[3]int64][]uint32)
+	for v := range canon {
+		if canon[v] == uint32(v) {
+			c := gridCell(m.vertex(uint32(v)), tol)
+			grid[c] = append(grid[c], uint32(v))
+		}
+	}
+
+	near := make(map[uint32]bool)
+	for c, members := range grid {
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for dz := int64(-1); dz <= 1; dz++ {
+					for _, u := range grid[[3]int64{c[0] + dx, c[1] + dy, c[2] + dz}] {
+						for _, v := range members {
+							if u != v && length(sub(m.vertex(u), m.vertex(v))) <= tol {
+								near[v] = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	count := 0
+	for v := range canon {
+		if near[canon[v]] {
+			count++
+		}
+	}
+	return count
+}
+
+// gridCell returns the cube of side size containing p.
+func gridCell(p [3]float64, size float64) [3]int64 {
+	return [3]int64{int64(math.Floor(p[0] / size)), int64(math.Floor(p[1] / size)), int64(math.Floor(p[2] / size))}
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestValidateCountsSeamAndNearVertices(t *testing.T) {
+	// Two triangles sharing an edge through exact copies, plus a third whose
+	// corner sits a hair away from the first triangle's.
+	m := &Mesh{
+		Vertices: []float32{
+			0, 0, 0, 1, 0, 0, 0, 1, 0,
+			1, 0, 0, 1, 1, 0, 0, 1, 0,
+			1e-7, 0, 0, 0, -1, 0, -1, 0, 0,
+		},
+		Indices: []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	r := m.validate()
+	if r.SeamVertices != 2 {
+		t.Errorf("SeamVertices = %d, want 2", r.SeamVertices)
+	}
+	if r.DuplicateVertices != 2 {
+		t.Errorf("DuplicateVertices = %d, want 2", r.DuplicateVertices)
+	}
+}
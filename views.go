@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// homeViewName names the fitted view offered for files without saved
+	// views.
+	homeViewName = "home"
+	// homeViewFOV matches the vertical field of view of the canvas camera.
+	homeViewFOV = 45
+	// maxViewsPerFile and maxViewFiles bound the views kept in settings.
+	maxViewsPerFile = 32
+	maxViewFiles    = 100
+)
+
+// NamedView is a camera pose saved under a name for one model file.
+type NamedView struct {
+	Name    string     `json:"name"`
+	Pose    CameraPose `json:"pose"`
+	SavedAt time.Time  `json:"savedAt" ts_type:"string"`
+	// Computed marks the fitted home view, which is not stored.
+	Computed bool `json:"computed,omitempty"`
+}
+
+// SaveView stores the camera pose under name for the file a mesh was loaded
+// from, replacing any view of that name. Views are kept in the settings,
+// keyed by the file's content hash, so they come back when the file is
+// reopened, even from another path.
+func (a *App) SaveView(meshID string, name string, pose CameraPose) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("save view: name is empty")
+	}
+	if err := pose.validate(); err != nil {
+		return fmt.Errorf("save view: %w", err)
+	}
+	path, err := a.meshes.source(meshID)
+	if err != nil {
+		return err
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return err
+	}
+	key := modelKey(path, m.Meta.Hash)
+
+	a.mu.Lock()
+	if a.settings.Views == nil {
+		a.settings.Views = make(map[string][]NamedView)
+	}
+	// Views saved before the hash was known move to the hash key.
+	views, old, _ := lookupModel(a.settings.Views, path, m.Meta.Hash)
+	views = slices.DeleteFunc(slices.Clone(views), func(v NamedView) bool { return v.Name == name })
+	if len(views) >= maxViewsPerFile {
+		a.mu.Unlock()
+		return fmt.Errorf("save view: %s already has %d views", filepath.Base(path), maxViewsPerFile)
+	}
+	if old != "" && old != key {
+		delete(a.settings.Views, old)
+	}
+	a.settings.Views[key] = append(views, NamedView{Name: name, Pose: pose, SavedAt: time.Now()})
+	pruneViews(a.settings.Views)
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+// GetViews returns the views saved for a model file, oldest first. When
+// there are none, a home view framing the model as GetCameraFit does is
+// returned instead, or nothing if the file cannot be read.
+func (a *App) GetViews(path string) []NamedView {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	sum := a.modelHash(abs)
+	a.mu.Lock()
+	views, _, _ := lookupModel(a.settings.Views, abs, sum)
+	views = slices.Clone(views)
+	a.mu.Unlock()
+	if len(views) > 0 {
+		return views
+	}
+
+	// A loaded copy may have been moved since, so it is preferred to the
+	// file, which is only peeked at for its bounding box.
+	var pose *CameraPose
+	fovY := homeViewFOV * math.Pi / 180
+	upAxis := a.upAxis()
+	if m, ok := a.meshes.lookupPath(abs); ok {
+		m.buffersMu.RLock()
+		pose = m.cameraFit(fovY, 1, upAxis)
+		m.buffersMu.RUnlock()
+	} else if p, err := a.PeekModel(abs); err == nil {
+		pose = fitSphere(scale(add(p.Min, p.Max), 0.5), length(sub(p.Max, p.Min))/2, upAxis, fovY, 1)
+	} else {
+		return []NamedView{}
+	}
+	return []NamedView{{Name: homeViewName, Pose: *pose, SavedAt: time.Now(), Computed: true}}
+}
+
+// DeleteView removes a saved view of a model file. Deleting a view that does
+// not exist is not an error.
+func (a *App) DeleteView(path string, name string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	sum := a.modelHash(abs)
+	a.mu.Lock()
+	views, key, _ := lookupModel(a.settings.Views, abs, sum)
+	n := len(views)
+	views = slices.DeleteFunc(views, func(v NamedView) bool { return v.Name == name })
+	if len(views) == n {
+		a.mu.Unlock()
+		return nil
+	}
+	if len(views) == 0 {
+		delete(a.settings.Views, key)
+	} else {
+		a.settings.Views[key] = views
+	}
+	a.mu.Unlock()
+	return a.persistSettings()
+}
+
+func (p CameraPose) validate() error {
+	for _, v := range [][3]float64{p.Eye, p.Target, p.Up} {
+		if !isFinite(v[0]) || !isFinite(v[1]) || !isFinite(v[2]) {
+			return fmt.Errorf("pose has a non-finite coordinate")
+		}
+	}
+	if p.Eye == p.Target {
+		return fmt.Errorf("camera eye and target coincide")
+	}
+	if p.Up == ([3]float64{}) {
+		return fmt.Errorf("up vector is zero")
+	}
+	return nil
+}
+
+// pruneViews drops the files whose latest view is oldest once more than
+// maxViewFiles have views.
+func pruneViews(views map[string][]NamedView) {
+	latest := func(key string) time.Time {
+		var t time.Time
+		for _, v := range views[key] {
+			if v.SavedAt.After(t) {
+				t = v.SavedAt
+			}
+		}
+		return t
+	}
+	for len(views) > maxViewFiles {
+		var oldest string
+		for key := range views {
+			if oldest == "" || latest(key).Before(latest(oldest)) {
+				oldest = key
+			}
+		}
+		delete(views, oldest)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import "fmt"
+
+// WeldResult is a copy of a mesh with nearby vertices shared.
+type WeldResult struct {
+	Mesh           *Mesh `json:"mesh"`
+	VerticesBefore int   `json:"verticesBefore"`
+	VerticesAfter  int   `json:"verticesAfter"`
+	// Tolerance is the distance actually used, which differs from the
+	// request when the default was chosen.
+	Tolerance float64 `json:"tolerance"`
+}
+
+// WeldVertices merges vertices within tolerance of each other and rebuilds
+// the index buffer; triangles are kept as they are, degenerate or not. A
+// tolerance of zero picks one millionth of the bounding box diagonal. With
+// matchAttributes, vertices must also share normals, UVs and colours, which
+// keeps seams; without it positions alone decide, each merged vertex takes
+// the UV and colour of the first one, and normals are recomputed smooth. The
+// result is registered as a new mesh.
+func (a *App) WeldVertices(meshID string, tolerance float64, matchAttributes bool) (*WeldResult, error) {
+	if !(tolerance >= 0) {
+		return nil, fmt.Errorf("weld: invalid tolerance %v", tolerance)
+	}
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	if m.VertexCount() == 0 {
+		m.buffersMu.RUnlock()
+		return nil, fmt.Errorf("weld: mesh %q has no vertices", meshID)
+	}
+	if tolerance == 0 {
+		min, max := m.bounds()
+		tolerance = length(sub(max, min)) * duplicateTolerance
+	}
+	res := &WeldResult{
+		Mesh:           m.weld(tolerance, matchAttributes),
+		VerticesBefore: m.VertexCount(),
+		Tolerance:      tolerance,
+	}
+	m.buffersMu.RUnlock()
+	res.VerticesAfter = res.Mesh.VertexCount()
+	a.meshes.add(res.Mesh)
+	return res, nil
+}
+
+func (m *Mesh) weld(tol float64, matchAttributes bool) *Mesh {
+	welded, reps := m.weldMap(m.weldClusters(tol), matchAttributes)
+	out := m.derived()
+	out.Indices = make([]uint32, len(m.Indices))
+	out.PointCloud = m.PointCloud
+	out.Groups = m.Groups
+	out.Attributes = m.Attributes
+	for i, v := range m.Indices {
+		out.Indices[i] = welded[v]
+	}
+	for _, v := range m.Lines {
+		out.Lines = append(out.Lines, welded[v])
+	}
+	for _, v := range m.Points {
+		out.Points = append(out.Points, welded[v])
+	}
+	hasNormals := len(m.Normals) == len(m.Vertices)
+	hasUVs := len(m.UVs) == m.VertexCount()*2
+	hasColors := len(m.Colors) == m.VertexCount()*4
+	for _, src := range reps {
+		out.Vertices = append(out.Vertices, m.Vertices[src*3:src*3+3]...)
+		if hasNormals && matchAttributes {
+			out.Normals = append(out.Normals, m.Normals[src*3:src*3+3]...)
+		}
+		if hasUVs {
+			out.UVs = append(out.UVs, m.UVs[src*2:src*2+2]...)
+		}
+		if hasColors {
+			out.Colors = append(out.Colors, m.Colors[src*4:src*4+4]...)
+		}
+	}
+	if hasNormals && !matchAttributes && !m.PointCloud {
+		out.smoothNormals()
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// WindingResult is a mesh after its triangle winding has been made
+// consistent.
+type WindingResult struct {
+	Mesh *Mesh `json:"mesh"`
+	// Flipped is the number of triangles whose corner order was reversed.
+	Flipped int `json:"flipped"`
+}
+
+// UnifyWinding reorders triangle corners so neighbouring triangles agree on
+// which side faces out. Each connected component is flood-filled across
+// shared edges from a seed triangle, then settles on the orientation most
+// of its triangles already had, keeping the flip count low. Vertices that
+// share a position count as connected, so UV seams do not split components.
+// Only Indices change, in place; stored normals are left as they are, so
+// RecomputeNormals should follow when the mesh has them.
+func (a *App) UnifyWinding(meshID string) (*WindingResult, error) {
+	m, err := a.meshes.get(meshID)
+	if err != nil {
+		return nil, err
+	}
+	m.buffersMu.RLock()
+	triangles := !m.PointCloud && m.TriangleCount() > 0
+	m.buffersMu.RUnlock()
+	if !triangles {
+		return nil, fmt.Errorf("unify winding: mesh %q has no triangles", meshID)
+	}
+	res := &WindingResult{Mesh: m, Flipped: m.unifyWinding()}
+	if res.Flipped > 0 && a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventModelUpdated, meshID, m.descriptor())
+	}
+	return res, nil
+}
+
+// unifyWinding flips inconsistently wound triangles and returns how many it
+// flipped. Where neighbours cannot agree, as on a Möbius strip, the first
+// orientation reached wins.
+func (m *Mesh) unifyWinding() int {
+	m.buffersMu.Lock()
+	defer m.buffersMu.Unlock()
+	canon := m.positionClasses()
+	count := m.TriangleCount()
+	corners := func(t int) [3]uint32 {
+		return [3]uint32{canon[m.Indices[t*3]], canon[m.Indices[t*3+1]], canon[m.Indices[t*3+2]]}
+	}
+	// runs reports whether triangle t, as stored, traverses from a to b.
+	runs := func(t int, a, b uint32) bool {
+		c := corners(t)
+		for k := 0; k < 3; k++ {
+			if c[k] == a && c[(k+1)%3] == b {
+				return true
+			}
+		}
+		return false
+	}
+
+	edges := make(map[uint64][]int, count*3/2)
+	for t := 0; t < count; t++ {
+		c := corners(t)
+		for k := 0; k < 3; k++ {
+			if a, b := c[k], c[(k+1)%3]; a != b {
+				edges[edgeKey(a, b)] = append(edges[edgeKey(a, b)], t)
+			}
+		}
+	}
+
+	flip := make([]bool, count)
+	visited := make([]bool, count)
+	var component, queue []int
+	flipped := 0
+	for seed := 0; seed < count; seed++ {
+		if visited[seed] {
+			continue
+		}
+		visited[seed] = true
+		component = append(component[:0], seed)
+		queue = append(queue[:0], seed)
+		for len(queue) > 0 {
+			t := queue[0]
+			queue = queue[1:]
+			c := corners(t)
+			if flip[t] {
+				c[1], c[2] = c[2], c[1]
+			}
+			for k := 0; k < 3; k++ {
+				a, b := c[k], c[(k+1)%3]
+				if a == b {
+					continue
+				}
+				for _, u := range edges[edgeKey(a, b)] {
+					if visited[u] {
+						continue
+					}
+					// A consistent neighbour crosses the shared edge from b
+					// to a.
+					visited[u] = true
+					flip[u] = runs(u, a, b)
+					component = append(component, u)
+					queue = append(queue, u)
+				}
+			}
+		}
+
+		n := 0
+		for _, t := range component {
+			if flip[t] {
+				n++
+			}
+		}
+		invert := 2*n > len(component)
+		for _, t := range component {
+			if flip[t] != invert {
+				m.Indices[t*3+1], m.Indices[t*3+2] = m.Indices[t*3+2], m.Indices[t*3+1]
+				flipped++
+			}
+		}
+	}
+	return flipped
+}
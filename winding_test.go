@@ -0,0 +1,53 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// signedVolume is positive for a closed mesh wound outward.
+func signedVolume(m *Mesh) float64 {
+	var v float64
+	for t := 0; t < m.TriangleCount(); t++ {
+		p0, p1, p2 := m.triangle(t)
+		v += dot(p0, cross(p1, p2)) / 6
+	}
+	return v
+}
+
+func TestUnifyWinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		flip    []int
+		flipped int
+		volume  float64
+	}{
+		{"consistent", nil, 0, 8},
+		{"one flipped triangle", []int{4}, 1, 8},
+		// Most of the cube faces inward, so the majority orientation wins.
+		{"mostly inverted", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 1, -8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewApp(defaultSettings())
+			m := cubeMesh(2, [3]float32{})
+			for _, tri := range tt.flip {
+				m.Indices[tri*3+1], m.Indices[tri*3+2] = m.Indices[tri*3+2], m.Indices[tri*3+1]
+			}
+			a.meshes.add(m)
+			res, err := a.UnifyWinding(m.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Flipped != tt.flipped {
+				t.Errorf("Flipped = %d, want %d", res.Flipped, tt.flipped)
+			}
+			if v := signedVolume(m); math.Abs(v-tt.volume) > 1e-4 {
+				t.Errorf("signed volume = %g, want %g", v, tt.volume)
+			}
+			if again, _ := a.UnifyWinding(m.ID); again.Flipped != 0 {
+				t.Errorf("second pass flipped %d", again.Flipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultWindowWidth  = 1024
+	defaultWindowHeight = 768
+
+	// minVisibleEdge is how much of the window must overlap the screen for a
+	// saved position to be reused.
+	minVisibleEdge = 100
+)
+
+// windowSize returns the initial window size from saved geometry.
+func (w WindowState) windowSize() (int, int) {
+	if w.Width <= 0 || w.Height <= 0 {
+		return defaultWindowWidth, defaultWindowHeight
+	}
+	return w.Width, w.Height
+}
+
+// startState returns the saved maximized state, or the build default when
+// nothing has been saved yet. Fullscreen builds always start fullscreen.
+func (w WindowState) startState() options.WindowStartState {
+	switch {
+	case w.Width <= 0, appWindowStartState == options.Fullscreen:
+		return appWindowStartState
+	case w.Maximized:
+		return options.Maximised
+	}
+	return options.Normal
+}
+
+// restoreWindowPosition moves the window to its saved position once it
+// exists. Positions that would leave the window off-screen (for example after
+// a monitor was disconnected) fall back to centring.
+func (a *App) restoreWindowPosition(ctx context.Context) {
+	a.mu.Lock()
+	w := a.settings.Window
+	a.mu.Unlock()
+
+	if w.Width <= 0 || w.Maximized || appWindowStartState == options.Fullscreen {
+		return
+	}
+	if !positionOnScreen(ctx, w) {
+		runtime.WindowCenter(ctx)
+		return
+	}
+	runtime.WindowSetPosition(ctx, w.X, w.Y)
+}
+
+// positionOnScreen reports whether the saved title bar area overlaps the
+// current screen. Wails reports window positions relative to that screen.
+func positionOnScreen(ctx context.Context, w WindowState) bool {
+	screens, err := runtime.ScreenGetAll(ctx)
+	if err != nil {
+		return false
+	}
+	for _, s := range screens {
+		if !s.IsCurrent {
+			continue
+		}
+		return w.X+w.Width >= minVisibleEdge && w.X <= s.Size.Width-minVisibleEdge &&
+			w.Y >= 0 && w.Y <= s.Size.Height-minVisibleEdge
+	}
+	return false
+}
+
+// saveWindowState records the current geometry. The normal size is kept when
+// the window is maximized so un-maximizing after a restart still works.
+// Nothing is saved after ResetSettings, so the defaults apply on next launch.
+func (a *App) saveWindowState(ctx context.Context) {
+	a.mu.Lock()
+	reset := a.windowReset
+	a.mu.Unlock()
+	if reset {
+		return
+	}
+
+	maximized := runtime.WindowIsMaximised(ctx)
+	fullscreen := runtime.WindowIsFullscreen(ctx)
+
+	a.mu.Lock()
+	w := &a.settings.Window
+	w.Maximized = maximized
+	if !maximized && !fullscreen && !runtime.WindowIsMinimised(ctx) {
+		w.Width, w.Height = runtime.WindowGetSize(ctx)
+		w.X, w.Y = runtime.WindowGetPosition(ctx)
+	} else if w.Width <= 0 {
+		w.Width, w.Height = defaultWindowWidth, defaultWindowHeight
+	}
+	a.mu.Unlock()
+
+	if err := a.persistSettings(); err != nil {
+		appLog.error("saving window state failed", "error", err)
+	}
+}